@@ -1,19 +1,70 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"news-topic-api/internal/db"
+	"news-topic-api/internal/repositories"
 	"news-topic-api/internal/routes"
+	"news-topic-api/internal/usecase"
+	"news-topic-api/internal/worker"
+	"os"
 	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
+const usage = `usage:
+  main [-migrate]            start the server, optionally applying pending migrations first
+  main migrate up            apply all pending migrations
+  main migrate down          roll back the last applied migration
+  main migrate status        list migrations and whether they are applied
+  main migrate create NAME   create a new migration file per driver in ` + db.MigrationsDir + `
+`
+
 func main() {
-	// init db
-	db, _ := db.NewPostgresDB()
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	migrateOnStart := flag.Bool("migrate", false, "apply pending migrations before starting the server")
+	flag.Parse()
+
+	// load config
+	config, _ := db.LoadConfig()
+
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(config, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// init repositories
+	repos, err := newRepositories(config, *migrateOnStart)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// publish scheduled news in the background
+	scheduleUc := usecase.NewNewsScheduleUseCase(repos.News, repos.UnitOfWork, validator.New())
+	go worker.NewPublisher(scheduleUc, config.PublishInterval).Run(context.Background())
+
+	// archive expired news in the background
+	expiryUc := usecase.NewNewsExpiryUseCase(repos.News, repos.UnitOfWork, validator.New())
+	go worker.NewExpirer(expiryUc, config.ExpireInterval).Run(context.Background())
+
+	// lift passed embargoes in the background
+	embargoUc := usecase.NewNewsEmbargoUseCase(repos.News, repos.UnitOfWork, validator.New())
+	go worker.NewEmbargoLifter(embargoUc, config.EmbargoInterval).Run(context.Background())
 
 	// init routes
-	r := routes.InitRoutes(db)
+	r := routes.InitRoutes(repos, routes.Options{
+		InternalToken: config.InternalToken,
+		AdminToken:    config.AdminToken,
+		EditLockTTL:   config.EditLockTTL,
+	})
 
 	server := &http.Server{
 		Addr:           ":9000",
@@ -25,9 +76,116 @@ func main() {
 
 	log.Printf("Server listening on %s", server.Addr)
 
-	err := server.ListenAndServe()
+	err = server.ListenAndServe()
 
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+func newRepositories(config *db.Config, migrate bool) (*repositories.Repositories, error) {
+	if config.DBDriver == db.DriverMemory {
+		log.Println("using in-memory storage")
+		return repositories.NewRepositoriesMemory(repositories.NewMemoryStore()), nil
+	}
+
+	cluster, err := db.OpenCluster(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if migrate {
+		if err := migrateUp(cluster.Primary()); err != nil {
+			return nil, err
+		}
+	}
+
+	go cluster.MonitorReplicas(context.Background(), config.DBReplicaCheckInterval)
+
+	timeouts := repositories.Timeouts{
+		Read:  config.DBReadTimeout,
+		Write: config.DBWriteTimeout,
+	}
+
+	return repositories.NewRepositoriesGorm(cluster.Primary(), cluster, timeouts), nil
+}
+
+func runMigrate(config *db.Config, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// create only writes a file, it does not need a database
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return fmt.Errorf("migrate create needs a name")
+		}
+		paths, err := db.CreateMigration(db.MigrationsDir, args[1])
+		for _, path := range paths {
+			log.Printf("created %s", path)
+		}
+		return err
+	}
+
+	if config.DBDriver == db.DriverMemory {
+		return fmt.Errorf("migrations are not used with the %s driver", db.DriverMemory)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return migrateUp(conn)
+	case "down":
+		migration, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		log.Printf("rolled back %d_%s", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-25s %d_%s\n", appliedAt, status.Version, status.Name)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	return nil
+}
+
+func migrateUp(conn *gorm.DB) error {
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up()
+	for _, migration := range applied {
+		log.Printf("applied %d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(applied) == 0 {
+		log.Println("no pending migrations")
+	}
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 )
 
 type Base struct {
-	Id   uint   `gorm:"type:int;not null auto_increment primary_key" `
-	UUID string `gorm:"type:string;default:gen_random_uuid()"`
+	Id uint `gorm:"type:int;not null auto_increment primary_key" `
+	// UUID is generated in BeforeCreate rather than by a gen_random_uuid()
+	// column default, so inserts work the same on every database.
+	UUID string `gorm:"type:string"`
 }
 
 func (b *Base) BeforeCreate(tx *gorm.DB) (err error) {
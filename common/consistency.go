@@ -0,0 +1,46 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// ReadPrimaryHeader asks for a read from the primary database instead of a
+// replica, so a client sees its own write before the replicas catch up.
+const ReadPrimaryHeader = "X-Read-Primary"
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads marks ctx so repository reads made under it skip the
+// replicas.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// PrimaryReads reports whether reads under ctx must go to the primary.
+func PrimaryReads(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return primary
+}
+
+// ReadPrimary keeps every read of a request on the primary when the client
+// sends X-Read-Primary: true, and for all writing requests, whose checks
+// must not act on a lagging replica.
+func ReadPrimary(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primary, _ := strconv.ParseBool(r.Header.Get(ReadPrimaryHeader))
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			primary = true
+		}
+
+		if primary {
+			r = r.WithContext(WithPrimaryReads(r.Context()))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ActorHeader names who is making a request. The API has no authentication
+// of its own, so it trusts whatever the gateway in front of it sets here.
+const ActorHeader = "X-Actor"
+
+// AnonymousActor is recorded when a request carries no actor.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor stores the actor making the changes under ctx.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor stored in ctx, or AnonymousActor.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// RequestIdFrom returns the id the RequestID middleware gave the request,
+// empty outside a request.
+func RequestIdFrom(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
+
+// Actor reads the X-Actor header into the request context and echoes the
+// request id back so clients can quote it.
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestId := RequestIdFrom(r.Context()); requestId != "" {
+			w.Header().Set(middleware.RequestIDHeader, requestId)
+		}
+
+		if actor := strings.TrimSpace(r.Header.Get(ActorHeader)); actor != "" {
+			r = r.WithContext(WithActor(r.Context(), actor))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,196 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// MaxDiffTokens caps the tokens of both texts together. The diff takes
+// time proportional to the tokens times the edits, so longer texts are
+// refused rather than tying up the server.
+const MaxDiffTokens = 20000
+
+var ErrDiffTooLarge = errors.New("texts are too long to diff")
+
+// DiffChunk is a run of text that is unchanged, inserted or deleted.
+// Concatenating the equal and delete chunks gives the old text, the equal
+// and insert chunks give the new one.
+type DiffChunk struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+var wordTokens = regexp.MustCompile(`\s+|\S+`)
+
+// DiffLines compares two texts line by line.
+func DiffLines(a, b string) ([]DiffChunk, error) {
+	return diffTokens(strings.SplitAfter(a, "\n"), strings.SplitAfter(b, "\n"))
+}
+
+// DiffWords compares two texts word by word, keeping whitespace as tokens
+// of its own so the chunks join back into the original texts.
+func DiffWords(a, b string) ([]DiffChunk, error) {
+	return diffTokens(wordTokens.FindAllString(a, -1), wordTokens.FindAllString(b, -1))
+}
+
+// diffTokens finds a shortest edit script and folds it into chunks. It
+// returns ErrDiffTooLarge past MaxDiffTokens.
+func diffTokens(a, b []string) ([]DiffChunk, error) {
+	if len(a)+len(b) > MaxDiffTokens {
+		return nil, fmt.Errorf("%w: %d tokens, at most %d", ErrDiffTooLarge, len(a)+len(b), MaxDiffTokens)
+	}
+
+	d := &differ{a: a, b: b, chunks: []DiffChunk{}}
+	d.diff(0, len(a), 0, len(b))
+
+	return d.chunks, nil
+}
+
+// differ runs the linear space variant of Myers' algorithm: it finds the
+// middle snake of a shortest edit script and recurses on both sides of it,
+// so it only keeps two rows of furthest reaching paths at a time.
+type differ struct {
+	a, b   []string
+	chunks []DiffChunk
+}
+
+// diff appends the chunks turning a[aLo:aHi] into b[bLo:bHi].
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.emit(DiffEqual, d.a[aLo])
+		aLo++
+		bLo++
+	}
+
+	suffixEnd := aHi
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	switch {
+	case aLo == aHi:
+		for _, token := range d.b[bLo:bHi] {
+			d.emit(DiffInsert, token)
+		}
+	case bLo == bHi:
+		for _, token := range d.a[aLo:aHi] {
+			d.emit(DiffDelete, token)
+		}
+	default:
+		// with the common ends gone both sides need at least one edit, so
+		// each half is smaller than the whole
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.diff(aLo, x, bLo, y)
+		for _, token := range d.a[x:u] {
+			d.emit(DiffEqual, token)
+		}
+		d.diff(u, aHi, v, bHi)
+	}
+
+	for _, token := range d.a[aHi:suffixEnd] {
+		d.emit(DiffEqual, token)
+	}
+}
+
+// middleSnake runs the search forwards from the start and backwards from
+// the end of a[aLo:aHi] and b[bLo:bHi] until the two meet, and returns the
+// snake where they did, from (x, y) to (u, v).
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+
+	// forward[offset+k] is the furthest x reached on diagonal k = x - y;
+	// backward[offset+c] is the furthest distance from the end reached on
+	// diagonal c = delta - k, walking the texts from their ends.
+	max := (n + m + 1) / 2
+	offset := max + 1
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+
+	for depth := 0; depth <= max; depth++ {
+		for k := -depth; k <= depth; k += 2 {
+			var fx int
+			if k == -depth || (k != depth && forward[offset+k-1] < forward[offset+k+1]) {
+				fx = forward[offset+k+1]
+			} else {
+				fx = forward[offset+k-1] + 1
+			}
+			fy := fx - k
+			startX, startY := fx, fy
+
+			for fx < n && fy < m && d.a[aLo+fx] == d.b[bLo+fy] {
+				fx++
+				fy++
+			}
+			forward[offset+k] = fx
+
+			if c := delta - k; odd && c >= -(depth-1) && c <= depth-1 && fx+backward[offset+c] >= n {
+				return aLo + startX, bLo + startY, aLo + fx, bLo + fy
+			}
+		}
+
+		for c := -depth; c <= depth; c += 2 {
+			var bx int
+			if c == -depth || (c != depth && backward[offset+c-1] < backward[offset+c+1]) {
+				bx = backward[offset+c+1]
+			} else {
+				bx = backward[offset+c-1] + 1
+			}
+			by := bx - c
+			startX, startY := bx, by
+
+			for bx < n && by < m && d.a[aHi-1-bx] == d.b[bHi-1-by] {
+				bx++
+				by++
+			}
+			backward[offset+c] = bx
+
+			if k := delta - c; !odd && k >= -depth && k <= depth && forward[offset+k]+bx >= n {
+				return aHi - bx, bHi - by, aHi - startX, bHi - startY
+			}
+		}
+	}
+
+	// the searches always meet by max
+	panic("diff: middle snake not found")
+}
+
+// emit appends a token, merging it into the last chunk when the op is the
+// same. A replacement always comes out as the delete before the insert,
+// whichever order the search found its edits in.
+func (d *differ) emit(op DiffOp, token string) {
+	if token == "" {
+		return
+	}
+
+	last := len(d.chunks) - 1
+	if op == DiffDelete && last >= 0 && d.chunks[last].Op == DiffInsert {
+		if last > 0 && d.chunks[last-1].Op == DiffDelete {
+			d.chunks[last-1].Text += token
+			return
+		}
+
+		insert := d.chunks[last]
+		d.chunks = append(d.chunks[:last], DiffChunk{Op: DiffDelete, Text: token}, insert)
+		return
+	}
+
+	if last >= 0 && d.chunks[last].Op == op {
+		d.chunks[last].Text += token
+		return
+	}
+
+	d.chunks = append(d.chunks, DiffChunk{Op: op, Text: token})
+}
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []DiffChunk
+	}{
+		{"same", "a\nb\n", "a\nb\n", []DiffChunk{{DiffEqual, "a\nb\n"}}},
+		{"both empty", "", "", []DiffChunk{}},
+		{"from empty", "", "a\n", []DiffChunk{{DiffInsert, "a\n"}}},
+		{"to empty", "a\n", "", []DiffChunk{{DiffDelete, "a\n"}}},
+		{
+			"changed middle line",
+			"one\ntwo\nthree\n",
+			"one\n2\nthree\n",
+			[]DiffChunk{{DiffEqual, "one\n"}, {DiffDelete, "two\n"}, {DiffInsert, "2\n"}, {DiffEqual, "three\n"}},
+		},
+		{
+			"line added at the end",
+			"one\ntwo",
+			"one\ntwo\nthree",
+			[]DiffChunk{{DiffEqual, "one\n"}, {DiffDelete, "two"}, {DiffInsert, "two\nthree"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffLines(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines(%q, %q) = %+v, want %+v", tt.a, tt.b, got, tt.want)
+			}
+			assertDiffJoins(t, got, tt.a, tt.b)
+		})
+	}
+}
+
+func TestDiffWords(t *testing.T) {
+	a := "the quick brown fox"
+	b := "the slow brown fox jumps"
+
+	want := []DiffChunk{
+		{DiffEqual, "the "},
+		{DiffDelete, "quick"},
+		{DiffInsert, "slow"},
+		{DiffEqual, " brown fox"},
+		{DiffInsert, " jumps"},
+	}
+
+	got, err := DiffWords(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffWords = %+v, want %+v", got, want)
+	}
+	assertDiffJoins(t, got, a, b)
+}
+
+func TestDiffTooLarge(t *testing.T) {
+	half := strings.Repeat("word ", MaxDiffTokens/4)
+
+	if _, err := DiffWords(half, half); err != nil {
+		t.Errorf("diffing %d tokens: %v", MaxDiffTokens, err)
+	}
+	if _, err := DiffWords(half, half+"more"); !errors.Is(err, ErrDiffTooLarge) {
+		t.Errorf("diffing past %d tokens: got %v, want ErrDiffTooLarge", MaxDiffTokens, err)
+	}
+}
+
+func TestDiffIsShortest(t *testing.T) {
+	pairs := [][2]string{
+		{"abcabba", "cbabac"},
+		{"xaxbxcx", "abc"},
+		{"kitten sitting", "sitting kitten"},
+		{"aaaa", "aa"},
+		{strings.Repeat("ab", 200), strings.Repeat("ba", 150) + "c"},
+	}
+
+	for _, pair := range pairs {
+		a, b := strings.Split(pair[0], ""), strings.Split(pair[1], "")
+		chunks, err := diffTokens(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertDiffJoins(t, chunks, pair[0], pair[1])
+
+		equal := 0
+		for i, chunk := range chunks {
+			if chunk.Op == DiffEqual {
+				equal += len(chunk.Text)
+			}
+			if i > 0 && chunk.Op == DiffDelete && chunks[i-1].Op == DiffInsert {
+				t.Errorf("%q -> %q has an insert before a delete: %+v", pair[0], pair[1], chunks)
+			}
+		}
+		if want := lcsLength(a, b); equal != want {
+			t.Errorf("%q -> %q keeps %d tokens, the longest common subsequence has %d", pair[0], pair[1], equal, want)
+		}
+	}
+}
+
+// assertDiffJoins checks the chunks give back both texts.
+func assertDiffJoins(t *testing.T, chunks []DiffChunk, a, b string) {
+	t.Helper()
+
+	var oldText, newText strings.Builder
+	for _, chunk := range chunks {
+		if chunk.Op != DiffInsert {
+			oldText.WriteString(chunk.Text)
+		}
+		if chunk.Op != DiffDelete {
+			newText.WriteString(chunk.Text)
+		}
+	}
+
+	if oldText.String() != a || newText.String() != b {
+		t.Errorf("chunks join into %q and %q, want %q and %q", oldText.String(), newText.String(), a, b)
+	}
+}
+
+func lcsLength(a, b []string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				rows[i][j] = rows[i+1][j+1] + 1
+			} else {
+				rows[i][j] = max(rows[i+1][j], rows[i][j+1])
+			}
+		}
+	}
+	return rows[0][0]
+}
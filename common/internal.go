@@ -0,0 +1,40 @@
+package common
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+)
+
+// InternalTokenHeader carries the shared secret that internal consumers,
+// such as the newsroom tools, send to read news that is still under
+// embargo.
+const InternalTokenHeader = "X-Internal-Token"
+
+type internalKey struct{}
+
+// WithInternal marks ctx as belonging to an internal caller.
+func WithInternal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalKey{}, true)
+}
+
+// IsInternal reports whether ctx belongs to an internal caller.
+func IsInternal(ctx context.Context) bool {
+	internal, _ := ctx.Value(internalKey{}).(bool)
+	return internal
+}
+
+// Internal marks requests that send token in X-Internal-Token as internal.
+// An empty token turns internal access off, so every caller is public.
+func Internal(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sent := r.Header.Get(InternalTokenHeader)
+			if token != "" && subtle.ConstantTimeCompare([]byte(sent), []byte(token)) == 1 {
+				r = r.WithContext(WithInternal(r.Context()))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
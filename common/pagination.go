@@ -1,14 +1,67 @@
 package common
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type Pagination struct {
 	Limit  int
 	Offset int
 	Page   int
+
+	// Keyset switches from offset paging to cursor paging: only rows
+	// ordered after Cursor are returned (all rows when Cursor is nil) and
+	// the repository sets NextCursor when more rows follow.
+	Keyset     bool
+	Cursor     *Cursor
+	NextCursor *Cursor
+}
+
+// Cursor is the position of a row in the "created_at desc, id desc" order.
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        uint      `json:"id"`
+}
+
+// After reports whether a row at (createdAt, id) comes after the cursor.
+func (c *Cursor) After(createdAt time.Time, id uint) bool {
+	if c == nil {
+		return true
+	}
+	if !createdAt.Equal(c.CreatedAt) {
+		return createdAt.Before(c.CreatedAt)
+	}
+	return id < c.Id
+}
+
+var (
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidPagination = errors.New("invalid pagination")
+)
+
+// Encode returns the opaque token handed to clients.
+func (c *Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
 }
 
 type MetaPage struct {
@@ -20,15 +73,22 @@ type MetaPage struct {
 	To          int `json:"to"`
 }
 
+type MetaCursor struct {
+	PerPage    int    `json:"per_page"`
+	Cursor     string `json:"cursor"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type Meta struct {
-	Pagination MetaPage `json:"pagination"`
+	Pagination *MetaPage   `json:"pagination,omitempty"`
+	Cursor     *MetaCursor `json:"cursor,omitempty"`
 }
 
 func NewMeta(totalItems, perPage, page, offset, itemsCount int) *Meta {
 	totalPages := (totalItems + perPage - 1) / perPage
 
 	return &Meta{
-		Pagination: MetaPage{
+		Pagination: &MetaPage{
 			Total:       totalItems,
 			PerPage:     perPage,
 			CurrentPage: page,
@@ -39,6 +99,18 @@ func NewMeta(totalItems, perPage, page, offset, itemsCount int) *Meta {
 	}
 }
 
+func NewCursorMeta(perPage int, cursor, nextCursor *Cursor) *Meta {
+	meta := &MetaCursor{PerPage: perPage}
+	if cursor != nil {
+		meta.Cursor = cursor.Encode()
+	}
+	if nextCursor != nil {
+		meta.NextCursor = nextCursor.Encode()
+	}
+
+	return &Meta{Cursor: meta}
+}
+
 func ExtractPaginationParams(r *http.Request, defaultPerPage, defaultPage int) (int, int) {
 	perPage := defaultPerPage
 	page := defaultPage
@@ -57,3 +129,20 @@ func ExtractPaginationParams(r *http.Request, defaultPerPage, defaultPage int) (
 
 	return perPage, page
 }
+
+// ExtractCursorParam reports whether the request asked for cursor paging,
+// which it does by sending a cursor parameter. An empty cursor starts from
+// the first row.
+func ExtractCursorParam(r *http.Request) (keyset bool, cursor *Cursor, err error) {
+	if !r.URL.Query().Has("cursor") {
+		return false, nil, nil
+	}
+
+	token := r.URL.Query().Get("cursor")
+	if token == "" {
+		return true, nil, nil
+	}
+
+	cursor, err = DecodeCursor(token)
+	return true, cursor, err
+}
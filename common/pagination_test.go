@@ -0,0 +1,90 @@
+package common
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCursorAfterBreaksTiesById(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cursor := &Cursor{CreatedAt: at, Id: 5}
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		id        uint
+		after     bool
+	}{
+		{"older", at.Add(-time.Second), 9, true},
+		{"newer", at.Add(time.Second), 1, false},
+		{"same time, lower id", at, 4, true},
+		{"same time, same id", at, 5, false},
+		{"same time, higher id", at, 6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cursor.After(tt.createdAt, tt.id); got != tt.after {
+				t.Errorf("After(%s, %d) = %v, want %v", tt.createdAt, tt.id, got, tt.after)
+			}
+		})
+	}
+
+	var none *Cursor
+	if !none.After(at, 1) {
+		t.Error("every row comes after a nil cursor")
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	want := &Cursor{CreatedAt: time.Date(2026, 10, 18, 12, 0, 0, 123, time.UTC), Id: 42}
+
+	got, err := DecodeCursor(want.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.Id != want.Id {
+		t.Errorf("round trip gave %+v, want %+v", got, want)
+	}
+
+	malformed := map[string]string{
+		"not base64":      "%%%",
+		"not json":        base64.RawURLEncoding.EncodeToString([]byte("created_at=now")),
+		"no created_at":   base64.RawURLEncoding.EncodeToString([]byte(`{"id":42}`)),
+		"bad created_at":  base64.RawURLEncoding.EncodeToString([]byte(`{"created_at":"yesterday","id":42}`)),
+		"padded encoding": base64.URLEncoding.EncodeToString([]byte(`{"created_at":"2026-10-18T12:00:00Z","id":4}`)),
+	}
+	for name, token := range malformed {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor(%q): got %v, want ErrInvalidCursor", token, err)
+			}
+		})
+	}
+}
+
+func TestExtractCursorParam(t *testing.T) {
+	tests := []struct {
+		query  string
+		keyset bool
+		cursor bool
+		err    error
+	}{
+		{"", false, false, nil},
+		{"?cursor=", true, false, nil},
+		{"?cursor=" + (&Cursor{CreatedAt: time.Now(), Id: 1}).Encode(), true, true, nil},
+		{"?cursor=garbage!", true, false, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			keyset, cursor, err := ExtractCursorParam(httptest.NewRequest("GET", "/news"+tt.query, nil))
+			if keyset != tt.keyset || (cursor != nil) != tt.cursor || !errors.Is(err, tt.err) {
+				t.Errorf("got keyset %v, cursor %v, err %v", keyset, cursor, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	slugPattern  = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	slugSplitter = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Slugify lowercases s and joins its runs of letters and digits with
+// hyphens, so "Jane O'Neil" becomes "jane-o-neil". Letters outside a-z are
+// dropped.
+func Slugify(s string) string {
+	return strings.Trim(slugSplitter.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// IsSlug reports whether s is lowercase letters and digits in groups
+// joined by single hyphens.
+func IsSlug(s string) bool {
+	return slugPattern.MatchString(s)
+}
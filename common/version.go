@@ -0,0 +1,43 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrStaleVersion is returned when a write was based on a version that
+	// is no longer the current one.
+	ErrStaleVersion = errors.New("the resource was changed since it was read, fetch it again and retry")
+	// ErrIfMatchRequired is returned when a write carries no If-Match header.
+	ErrIfMatchRequired = errors.New("If-Match header is required, send the ETag of the resource being changed")
+	ErrInvalidIfMatch  = errors.New("If-Match must be an ETag returned by this API")
+)
+
+// ETag formats a version as a strong entity tag.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ExtractIfMatch reads the version a write is based on from the If-Match
+// header. Weak tags are accepted since versions are never reused.
+func ExtractIfMatch(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, ErrIfMatchRequired
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
@@ -9,27 +9,36 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {},
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@swagger.io"
+        },
+        "license": {
+            "name": "MIT",
+            "url": "https://opensource.org/licenses/MIT"
+        },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/news": {
+        "/audit": {
             "get": {
-                "description": "Get all news with pagination",
+                "description": "Get the recorded news and topic changes, newest first. For callers without X-Internal-Token, events holding embargoed news come without before and after, flagged as redacted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Audit"
                 ],
-                "summary": "Get all news",
+                "summary": "Get audit events",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 5,
-                        "description": "Number of news per page",
+                        "default": 20,
+                        "description": "Number of events per page",
                         "name": "per_page",
                         "in": "query"
                     },
@@ -41,21 +50,38 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "news",
+                            "topic",
+                            "author"
+                        ],
                         "type": "string",
-                        "description": "Filter news by title",
-                        "name": "filter",
+                        "description": "Only events of this entity type",
+                        "name": "entity_type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter news by topic",
-                        "name": "topic",
+                        "description": "Only events of this news item or topic",
+                        "name": "entity_uuid",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter news by status",
-                        "name": "status",
+                        "description": "Only events made by this actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events before this RFC 3339 time",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -65,7 +91,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.Response"
+                                "$ref": "#/definitions/response.AuditEventResponse"
                             }
                         }
                     },
@@ -82,41 +108,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create news",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/authors": {
+            "get": {
+                "description": "Get all authors, ordered by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Authors"
                 ],
-                "summary": "Create news",
+                "summary": "Get all authors",
                 "parameters": [
                     {
-                        "description": "Create news",
-                        "name": "news",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateNewsRequest"
-                        }
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of authors per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.NewsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
@@ -127,8 +151,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete all existing news",
+            "post": {
+                "description": "Create an author. The slug is derived from the name when it is left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,14 +160,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Authors"
+                ],
+                "summary": "Create a new author",
+                "parameters": [
+                    {
+                        "description": "Create Author Request",
+                        "name": "author",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAuthorRequest"
+                        }
+                    }
                 ],
-                "summary": "Delete all news",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/response.NewsResponse"
+                            "$ref": "#/definitions/response.AuthorResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version"
+                            }
                         }
                     },
                     "400": {
@@ -152,8 +193,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Slug taken",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -167,20 +208,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/news/{uuid}": {
+        "/authors/{uuid}": {
             "get": {
-                "description": "Get news by uuid",
+                "description": "Get author by uuid",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Authors"
                 ],
-                "summary": "Get news by uuid",
+                "summary": "Get author by uuid",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News UUID",
+                        "description": "Author UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -190,11 +231,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.NewsResponse"
+                            "$ref": "#/definitions/response.AuthorResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version, send it back as If-Match when writing"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -208,7 +255,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing news item by its UUID",
+                "description": "Update the fields that are sent, leaving the others as they are",
                 "consumes": [
                     "application/json"
                 ],
@@ -216,24 +263,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Authors"
                 ],
-                "summary": "Update news by UUID",
+                "summary": "Update author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News UUID",
+                        "description": "Author UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "News data",
-                        "name": "news",
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Author Request",
+                        "name": "author",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateNewsRequest"
+                            "$ref": "#/definitions/dtos.UpdateAuthorRequest"
                         }
                     }
                 ],
@@ -241,7 +295,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.AuthorResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
                         }
                     },
                     "400": {
@@ -256,6 +316,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Slug taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current author is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -263,50 +341,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/news/{uuid}/status": {
-            "put": {
-                "description": "Update news status",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Delete an author and take them out of every byline",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Authors"
                 ],
-                "summary": "Update news status",
+                "summary": "Delete author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News UUID",
+                        "description": "Author UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "News data",
-                        "name": "news",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UpdateNewsStatus"
-                        }
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.NewsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "404": {
@@ -315,48 +380,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/topic": {
-            "post": {
-                "description": "Create a new topic with the specified name",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Topics"
-                ],
-                "summary": "Create a new topic",
-                "parameters": [
-                    {
-                        "description": "Create Topic Request",
-                        "name": "topic",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateTopicRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    "412": {
+                        "description": "Changed since it was read, the current author is in data",
                         "schema": {
-                            "$ref": "#/definitions/response.TopicResponse"
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "428": {
+                        "description": "If-Match header missing",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -370,36 +401,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/topic/{uuid}": {
+        "/authors/{uuid}/news": {
             "get": {
-                "description": "Get topic by uuid",
+                "description": "Get the news with the author in its byline, newest first. Works like GET /news?author={slug}.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Topics"
+                    "Authors"
                 ],
-                "summary": "Get topic by uuid",
+                "summary": "Get news by an author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Topic UUID",
+                        "description": "Author UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of news per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter news by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list archived news",
+                        "name": "include_archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.TopicResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "404": {
@@ -415,34 +466,137 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update topic",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/news": {
+            "get": {
+                "description": "Get all news with offset pagination, or with cursor pagination when a cursor parameter is sent (empty for the first page)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Topics"
+                    "News"
                 ],
-                "summary": "Update topic",
+                "summary": "Get all news",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of news per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Topic UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Opaque cursor from meta.cursor.next_cursor; send it empty to start cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "description": "Update Topic Request",
-                        "name": "topic",
-                        "in": "body",
+                        "type": "string",
+                        "description": "Filter news by title",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter news by topic",
+                        "name": "topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With topic, also list news of its descendant topics",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter news by author slug",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter news by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list archived news, which are left out unless status asks for them",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search over title and content, ranked by relevance",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "simple",
+                            "english",
+                            "indonesian"
+                        ],
+                        "type": "string",
+                        "description": "Text search language for q",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Response"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Create news",
+                "parameters": [
+                    {
+                        "description": "Create news",
+                        "name": "news",
+                        "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateTopicRequest"
+                            "$ref": "#/definitions/dtos.CreateNewsRequest"
                         }
                     }
                 ],
@@ -450,7 +604,49 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.TopicResponse"
+                            "$ref": "#/definitions/response.NewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete all existing news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Delete all news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
                         }
                     },
                     "400": {
@@ -465,6 +661,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -472,9 +686,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete topic",
+            }
+        },
+        "/news/bulk": {
+            "post": {
+                "description": "Publish, archive, delete, add topics to or remove topics from up to 500 news items, named by UUID or matched by a filter like the one of GET /news. In transaction mode (the default) one failing item rolls back all others; in per_item mode each item is committed on its own. Items do not need If-Match, their current version is used.",
                 "consumes": [
                     "application/json"
                 ],
@@ -482,23 +698,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Topics"
+                    "News"
                 ],
-                "summary": "Delete topic",
+                "summary": "Run an action on many news items",
+                "parameters": [
+                    {
+                        "description": "Action and items",
+                        "name": "bulk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BulkNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Every item succeeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkNewsResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some items failed, see results",
+                        "schema": {
+                            "$ref": "#/definitions/response.BulkNewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/status/{uuid}": {
+            "put": {
+                "description": "Update news status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Update news status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Topic UUID",
+                        "description": "News UUID",
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "News data",
+                        "name": "news",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateNewsStatus"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
                         }
                     },
                     "400": {
@@ -513,6 +803,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -522,21 +830,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/topics": {
+        "/news/trash": {
             "get": {
-                "description": "Get all topics with pagination",
+                "description": "Get soft-deleted news, most recently deleted first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Topics"
+                    "News Trash"
                 ],
-                "summary": "Get all topics",
+                "summary": "Get deleted news",
                 "parameters": [
                     {
                         "type": "integer",
                         "default": 5,
-                        "description": "Number of topics per page",
+                        "description": "Number of news per page",
                         "name": "per_page",
                         "in": "query"
                     },
@@ -552,16 +860,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.Response"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
@@ -572,57 +871,3824 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "common.Meta": {
+        },
+        "/news/trash/{uuid}": {
+            "delete": {
+                "description": "Permanently delete a news item from the trash, together with its revisions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Trash"
+                ],
+                "summary": "Permanently delete news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/trash/{uuid}/restore": {
+            "post": {
+                "description": "Take a news item out of the trash. It is restored as a draft.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Trash"
+                ],
+                "summary": "Restore deleted news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}": {
+            "get": {
+                "description": "Get news by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Get news by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version, send it back as If-Match when writing"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing news item by its UUID. Lock it with POST /news/{uuid}/lock first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Update news by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "News data",
+                        "name": "news",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/comments": {
+            "get": {
+                "description": "Get the review comment threads of a news item, oldest first, with replies nested under the comment they answer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Review Comments"
+                ],
+                "summary": "Get news review comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsReviewCommentsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a review thread, optionally anchored to a character range of the content, or reply to a comment with parent_uuid. The author is taken from X-Actor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Review Comments"
+                ],
+                "summary": "Comment on news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateNewsReviewCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsReviewCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "New thread on scheduled news",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/comments/{comment}/reopen": {
+            "post": {
+                "description": "Reopen a resolved thread",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Review Comments"
+                ],
+                "summary": "Reopen a review thread",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the first comment of the thread",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsReviewCommentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not resolved, not the first comment of a thread, or the news is scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/comments/{comment}/resolve": {
+            "post": {
+                "description": "Resolve the thread started by the comment. Publishing is blocked while threads are unresolved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Review Comments"
+                ],
+                "summary": "Resolve a review thread",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the first comment of the thread",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsReviewCommentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already resolved, or not the first comment of a thread",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/embargo": {
+            "put": {
+                "description": "Hide news from public reads until embargo_until. Only callers sending X-Internal-Token can embargo news, and they still see it, flagged as embargoed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Embargo"
+                ],
+                "summary": "Embargo news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Embargo end",
+                        "name": "embargo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EmbargoNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "X-Internal-Token missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "End the embargo now instead of at embargo_until. Needs X-Internal-Token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Embargo"
+                ],
+                "summary": "Lift a news embargo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "X-Internal-Token missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not under embargo",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/expiry": {
+            "put": {
+                "description": "Set when news is archived. Published news is archived by the expirer once expires_at has passed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Expiry"
+                ],
+                "summary": "Set news expiry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Expiry time",
+                        "name": "expiry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SetNewsExpiryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Keep news published until it is archived by hand",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Expiry"
+                ],
+                "summary": "Clear news expiry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/lock": {
+            "get": {
+                "description": "Show who holds the edit lock and until when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Locks"
+                ],
+                "summary": "Get the edit lock of news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsLockResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "News not found or not locked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Extend the caller's lock by EDIT_LOCK_TTL from now. Fails once the lease has run out; lock again to take it back.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Locks"
+                ],
+                "summary": "Extend an edit lock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holder of the lock",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsLockResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Lock not held",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Check the news out to the X-Actor of the request for EDIT_LOCK_TTL. Locking again while holding the lock extends it. Editing news needs the lock: edits by anyone else are rejected with 423, and edits of news nobody locked with 428.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Locks"
+                ],
+                "summary": "Lock news for editing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who takes the lock",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsLockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "X-Actor header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Check the news back in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Locks"
+                ],
+                "summary": "Unlock news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holder of the lock",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not locked, or locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/lock/break": {
+            "post": {
+                "description": "Remove the lock whoever holds it, for instance after an editor left without unlocking. Needs X-Admin-Token. Recorded as a lock_break audit event.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Locks"
+                ],
+                "summary": "Break an edit lock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin token",
+                        "name": "X-Admin-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not locked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/pending": {
+            "get": {
+                "description": "Get the working copy of published news as it will read once published",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Pending Edits"
+                ],
+                "summary": "Preview the pending edit of news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsPendingEditResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the working copy"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the working copy. Empty fields are left as they are; topics, when sent, replace the current ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Pending Edits"
+                ],
+                "summary": "Edit the pending copy of published news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the working copy",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "edit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EditPendingNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsPendingEditResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the working copy"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current working copy is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Copy the live content of published news into a working copy. The live news is unchanged until the copy is published.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Pending Edits"
+                ],
+                "summary": "Start a pending edit of published news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the live news",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsPendingEditResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the working copy"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not published or already has a pending edit",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Throw the working copy away. The live news is unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Pending Edits"
+                ],
+                "summary": "Discard the pending edit of published news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the working copy",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current working copy is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/pending/publish": {
+            "post": {
+                "description": "Replace the live content with the working copy in one transaction and remove the copy. Topics deleted since they were picked are skipped. Like publishing, it waits until every review comment is resolved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Pending Edits"
+                ],
+                "summary": "Publish the pending edit of published news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the working copy being published",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the live news"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not published or has unresolved review comments",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current working copy is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/revisions": {
+            "get": {
+                "description": "Get the revisions of a news item, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Revisions"
+                ],
+                "summary": "Get news revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of revisions per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/revisions/diff": {
+            "get": {
+                "description": "Compare two revisions of a news item. Title and content are diffed line by line or word by word; status, language and topic changes are listed separately. Texts of more than 20000 lines or words and spaces together are refused with 422.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Revisions"
+                ],
+                "summary": "Diff two news revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Older revision number",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Newer revision number",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "line",
+                            "word"
+                        ],
+                        "type": "string",
+                        "default": "line",
+                        "description": "Diff granularity",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsRevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Texts too long to diff",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/revisions/{revision}": {
+            "get": {
+                "description": "Get one revision of a news item by its number",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Revisions"
+                ],
+                "summary": "Get a news revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsRevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/revisions/{revision}/restore": {
+            "post": {
+                "description": "Copy a past revision back onto the news item as a draft. Topics deleted since then are skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Revisions"
+                ],
+                "summary": "Restore a news revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the current news version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Locked by someone else",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing, or the news is not locked by you",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/schedule": {
+            "put": {
+                "description": "Move the publish time of scheduled news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Schedule"
+                ],
+                "summary": "Reschedule news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New publish time",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ScheduleNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedule approved news to be published at publish_at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Schedule"
+                ],
+                "summary": "Schedule news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Publish time",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ScheduleNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not approved",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Take news off the schedule, it goes back to approved",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Schedule"
+                ],
+                "summary": "Cancel scheduled publishing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/transitions": {
+            "get": {
+                "description": "List the statuses a news item can move to next. Moves blocked by a guard are listed with allowed false and the reason.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Get news status transitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsTransitionsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{uuid}/unarchive": {
+            "post": {
+                "description": "Publish archived news again. An expiry time that has already passed is cleared.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News Expiry"
+                ],
+                "summary": "Unarchive news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News is not archived",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current news is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topic": {
+            "post": {
+                "description": "Create a new topic with the specified name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Create a new topic",
+                "parameters": [
+                    {
+                        "description": "Create Topic Request",
+                        "name": "topic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateTopicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Value is an alias of a topic",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topic/{uuid}": {
+            "get": {
+                "description": "Get topic by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Get topic by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version, send it back as If-Match when writing"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update topic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Update topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Topic Request",
+                        "name": "topic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateTopicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Parent is the topic itself or one of its descendants",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current topic is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete topic",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Delete topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Topic still has child topics",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current topic is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics": {
+            "get": {
+                "description": "Get all topics with offset pagination, or with cursor pagination when a cursor parameter is sent (empty for the first page)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Get all topics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of topics per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.cursor.next_cursor; send it empty to start cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Response"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/lookup": {
+            "get": {
+                "description": "Find the topic a value names, either as its own value or as one of its aliases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Look up a topic by value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic value or alias",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Current version, send it back as If-Match when writing"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/trash": {
+            "get": {
+                "description": "Get soft-deleted topics, most recently deleted first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics Trash"
+                ],
+                "summary": "Get deleted topics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of topics per page",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Current page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/trash/{uuid}": {
+            "delete": {
+                "description": "Permanently delete a topic from the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics Trash"
+                ],
+                "summary": "Permanently delete topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/trash/{uuid}/restore": {
+            "post": {
+                "description": "Take a topic out of the trash and attach it again to the news it was linked to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics Trash"
+                ],
+                "summary": "Restore deleted topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/tree": {
+            "get": {
+                "description": "Get every topic nested under its parent, each level ordered by title",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Get the topic tree",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{uuid}/aliases": {
+            "get": {
+                "description": "Get the other values a topic answers to in GET /news?topic=..., by value",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Aliases"
+                ],
+                "summary": "Get topic aliases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TopicAliasResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add another value the topic answers to. It cannot be the value of any topic or another alias.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Aliases"
+                ],
+                "summary": "Add a topic alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TopicAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicAliasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Value already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{uuid}/aliases/{alias_uuid}": {
+            "put": {
+                "description": "Change the value of a topic alias",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Aliases"
+                ],
+                "summary": "Rename a topic alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias UUID",
+                        "name": "alias_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TopicAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicAliasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Value already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a topic alias; news filters on its value stop matching the topic",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Aliases"
+                ],
+                "summary": "Remove a topic alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias UUID",
+                        "name": "alias_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{uuid}/merge": {
+            "post": {
+                "description": "Move the news, pins and child topics of the topic to the target topic in one transaction, then delete the topic for good. Its value stays behind as an alias of the target, so news filters on it keep working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topics"
+                ],
+                "summary": "Merge a topic into another",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the topic merged away",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being merged",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Target topic",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MergeTopicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TopicMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Target is below the topic",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Changed since it was read, the current topic is in data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{uuid}/pins": {
+            "get": {
+                "description": "Get the news pinned to a topic, in the order they are listed first by GET /news?topic=...",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Pins"
+                ],
+                "summary": "Get topic pins",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TopicPinResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put the pinned news of a topic in a new order. Every pinned news item must be listed exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Pins"
+                ],
+                "summary": "Reorder topic pins",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "News UUIDs in their new order",
+                        "name": "pins",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReorderTopicPinsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TopicPinResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Pins changed since they were read",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Pin a news item of the topic at a position, optionally until expires_at. Pinning news that is already pinned moves it and replaces its expiry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Pins"
+                ],
+                "summary": "Pin news to a topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pin",
+                        "name": "pin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PinNewsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TopicPinResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "News not in the topic",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{uuid}/pins/{news_uuid}": {
+            "delete": {
+                "description": "Unpin a news item; the pins after it move up",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Topic Pins"
+                ],
+                "summary": "Unpin news from a topic",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Topic UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "news_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TopicPinResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "common.DiffChunk": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "$ref": "#/definitions/common.DiffOp"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.DiffOp": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "DiffEqual",
+                "DiffInsert",
+                "DiffDelete"
+            ]
+        },
+        "common.Meta": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "$ref": "#/definitions/common.MetaCursor"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/common.MetaPage"
+                }
+            }
+        },
+        "common.MetaCursor": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "per_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "common.MetaPage": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "last_page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.AuthorUuid": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.BulkNewsRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "uuids"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "publish",
+                        "archive",
+                        "delete",
+                        "add_topics",
+                        "remove_topics"
+                    ]
+                },
+                "filter": {
+                    "$ref": "#/definitions/dtos.FilterNewsRequest"
+                },
+                "mode": {
+                    "description": "Mode is transaction (the default), where one failure rolls back every\nitem, or per_item, where each item is committed on its own.",
+                    "type": "string",
+                    "enum": [
+                        "transaction",
+                        "per_item"
+                    ]
+                },
+                "topics": {
+                    "description": "Topics are added or removed by the add_topics and remove_topics\nactions.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TopicUuid"
+                    }
+                },
+                "uuids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.CreateAuthorRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "slug": {
+                    "description": "Slug is derived from Name when it is left empty.",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dtos.CreateNewsRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "status",
+                "title"
+            ],
+            "properties": {
+                "authors": {
+                    "description": "Authors make up the byline, in the order given.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AuthorUuid"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "embargo_until": {
+                    "description": "EmbargoUntil hides the news from public reads until then.",
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "simple",
+                        "english",
+                        "indonesian"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "in_review"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TopicUuid"
+                    }
+                }
+            }
+        },
+        "dtos.CreateNewsReviewCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "anchor": {
+                    "description": "Anchor ties a thread to part of the content. Replies cannot have one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.NewsCommentAnchor"
+                        }
+                    ]
+                },
+                "body": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "ParentUuid makes the comment a reply in the thread of that comment.",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateTopicRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "parent_uuid": {
+                    "description": "ParentUuid places the new topic under another one.",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EditPendingNewsRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "simple",
+                        "english",
+                        "indonesian"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TopicUuid"
+                    }
+                }
+            }
+        },
+        "dtos.EmbargoNewsRequest": {
+            "type": "object",
+            "required": [
+                "embargo_until"
+            ],
+            "properties": {
+                "embargo_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.FilterNewsRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Author is the slug of an author in the byline.",
+                    "type": "string"
+                },
+                "include_archived": {
+                    "description": "IncludeArchived lists archived news too. Without it archived news is\nonly listed when Status asks for it.",
+                    "type": "boolean"
+                },
+                "include_descendants": {
+                    "description": "IncludeDescendants widens Topic to the topics below it.",
+                    "type": "boolean"
+                },
+                "lang": {
+                    "type": "string",
+                    "enum": [
+                        "simple",
+                        "english",
+                        "indonesian"
+                    ]
+                },
+                "q": {
+                    "description": "Query is a full-text search over title and content, stemmed with\nLanguage or, when no language is given, with every supported one.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MergeTopicRequest": {
+            "type": "object",
+            "required": [
+                "target_uuid"
+            ],
+            "properties": {
+                "target_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.NewsCommentAnchor": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.PinNewsRequest": {
+            "type": "object",
+            "required": [
+                "news_uuid"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt unpins the news once it passes.",
+                    "type": "string"
+                },
+                "news_uuid": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position is where the pin goes, starting at 1. Zero, or a position\npast the last pin, puts it last.",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.ReorderTopicPinsRequest": {
+            "type": "object",
+            "required": [
+                "news"
+            ],
+            "properties": {
+                "news": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.ScheduleNewsRequest": {
+            "type": "object",
+            "required": [
+                "publish_at"
+            ],
+            "properties": {
+                "publish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.SetNewsExpiryRequest": {
+            "type": "object",
+            "required": [
+                "expires_at"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.TopicAliasRequest": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dtos.TopicUuid": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UpdateAuthorRequest": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dtos.UpdateNewsRequest": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "description": "Authors, when sent, replace the byline in the order given.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AuthorUuid"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "simple",
+                        "english",
+                        "indonesian"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "in_review",
+                        "approved",
+                        "scheduled",
+                        "published",
+                        "archived",
+                        "deleted"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TopicUuid"
+                    }
+                }
+            }
+        },
+        "dtos.UpdateNewsStatus": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "in_review",
+                        "approved",
+                        "scheduled",
+                        "published",
+                        "archived",
+                        "deleted"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateTopicRequest": {
+            "type": "object",
+            "properties": {
+                "parent_uuid": {
+                    "description": "ParentUuid moves the topic under another one when it is sent, or to\nthe root when it is sent empty.",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "entity_uuid": {
+                    "type": "string"
+                },
+                "redacted": {
+                    "description": "Redacted is set when before and after were left out because they\nhold embargoed news.",
+                    "type": "boolean"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.BulkNewsResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BulkNewsResultResponse"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.BulkNewsResultResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is the new version of changed news.",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NewsCommentAnchorResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "outdated": {
+                    "description": "Outdated is true once the anchored characters of the content no longer\nread Text.",
+                    "type": "boolean"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NewsHighlights": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NewsLockResponse": {
             "type": "object",
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/common.MetaPage"
+                "acquired_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "holder": {
+                    "type": "string"
                 }
             }
         },
-        "common.MetaPage": {
+        "response.NewsPendingEditResponse": {
             "type": "object",
             "properties": {
-                "current_page": {
-                    "type": "integer"
+                "content": {
+                    "type": "string"
                 },
-                "from": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "last_page": {
-                    "type": "integer"
+                "language": {
+                    "type": "string"
                 },
-                "per_page": {
-                    "type": "integer"
+                "news_uuid": {
+                    "type": "string"
                 },
-                "to": {
-                    "type": "integer"
+                "title": {
+                    "type": "string"
                 },
-                "total": {
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TopicResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.CreateNewsRequest": {
+        "response.NewsResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "status",
-                "title"
-            ],
             "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuthorResponse"
+                    }
+                },
                 "content": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "embargo_until": {
+                    "type": "string"
+                },
+                "embargoed": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "highlights": {
+                    "$ref": "#/definitions/response.NewsHighlights"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "pin_position": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
                 "status": {
-                    "type": "string",
-                    "enum": [
-                        "published",
-                        "draft"
-                    ]
+                    "type": "string"
                 },
                 "title": {
                     "type": "string"
@@ -630,44 +4696,126 @@ const docTemplate = `{
                 "topics": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.TopicUuid"
+                        "$ref": "#/definitions/response.TopicResponse"
                     }
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.CreateTopicRequest": {
+        "response.NewsReviewCommentResponse": {
             "type": "object",
-            "required": [
-                "title"
-            ],
             "properties": {
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                "anchor": {
+                    "$ref": "#/definitions/response.NewsCommentAnchorResponse"
                 },
-                "value": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NewsReviewCommentResponse"
+                    }
+                },
+                "resolved": {
+                    "type": "boolean"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "uuid": {
                     "type": "string"
                 }
             }
         },
-        "dtos.TopicUuid": {
+        "response.NewsReviewCommentsResponse": {
             "type": "object",
-            "required": [
-                "uuid"
-            ],
             "properties": {
-                "uuid": {
+                "threads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NewsReviewCommentResponse"
+                    }
+                },
+                "unresolved": {
+                    "description": "Unresolved counts the open threads, which hold back publishing.",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.NewsRevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.DiffChunk"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "language": {
+                    "$ref": "#/definitions/response.ValueChange"
+                },
+                "mode": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/response.ValueChange"
+                },
+                "title": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.DiffChunk"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "topics_added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TopicResponse"
+                    }
+                },
+                "topics_removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TopicResponse"
+                    }
                 }
             }
         },
-        "dtos.UpdateNewsRequest": {
+        "response.NewsRevisionResponse": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -677,92 +4825,137 @@ const docTemplate = `{
                 "topics": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.TopicUuid"
+                        "$ref": "#/definitions/response.TopicResponse"
                     }
+                },
+                "uuid": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.UpdateNewsStatus": {
+        "response.NewsTransitionResponse": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "published",
-                        "draft"
-                    ]
+                "allowed": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.UpdateTopicRequest": {
+        "response.NewsTransitionsResponse": {
             "type": "object",
             "properties": {
-                "title": {
+                "status": {
                     "type": "string"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NewsTransitionResponse"
+                    }
                 }
             }
         },
-        "response.ErrorResponse": {
+        "response.Response": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
+                "data": {},
                 "message": {
                     "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/common.Meta"
                 }
             }
         },
-        "response.NewsResponse": {
+        "response.TopicAliasResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "created_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "uuid": {
+                    "type": "string"
                 },
-                "status": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.TopicMergeResponse": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Alias is the value of the merged topic, which now resolves to Topic.",
                     "type": "string"
                 },
+                "news_moved": {
+                    "description": "NewsMoved counts the news that gained the topic. News that already\nhad both topics are not counted.",
+                    "type": "integer"
+                },
+                "topic": {
+                    "$ref": "#/definitions/response.TopicResponse"
+                }
+            }
+        },
+        "response.TopicPathResponse": {
+            "type": "object",
+            "properties": {
                 "title": {
                     "type": "string"
                 },
-                "topics": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.TopicResponse"
-                    }
-                },
                 "uuid": {
                     "type": "string"
+                },
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "response.Response": {
+        "response.TopicPinResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "expires_at": {
+                    "type": "string"
                 },
-                "data": {},
-                "message": {
+                "news": {
+                    "$ref": "#/definitions/response.NewsResponse"
+                },
+                "pinned_at": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/common.Meta"
+                "position": {
+                    "type": "integer"
                 }
             }
         },
         "response.TopicResponse": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "parent_uuid": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path is the breadcrumb from the root topic down to this one. It is\nonly filled by the topic endpoints.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TopicPathResponse"
+                    }
+                },
                 "title": {
                     "type": "string"
                 },
@@ -771,6 +4964,20 @@ const docTemplate = `{
                 },
                 "value": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ValueChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         }
@@ -779,12 +4986,12 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "1.0.0",
+	Version:          "2.0",
 	Host:             "localhost:9000",
 	BasePath:         "/api/v1",
-	Schemes:          []string{},
-	Title:            "Docs News Topic API",
-	Description:      "This is a sample server celler server.",
+	Schemes:          []string{"http"},
+	Title:            "News Topic API",
+	Description:      "This is a sample server for managing news topics.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// replicaPingTimeout bounds a single health check of a replica.
+const replicaPingTimeout = 2 * time.Second
+
+// Cluster is the primary database together with its read replicas. Writes
+// and transactions always use the primary; replicas take list and get
+// queries while they answer their health checks.
+type Cluster struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// OpenCluster connects to the primary selected by config.DBDriver and to
+// every replica in config.DBReplicas. A replica that cannot be reached
+// starts out of rotation instead of failing startup.
+func OpenCluster(config *Config) (*Cluster, error) {
+	primary, err := Open(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.DBReplicas) > 0 && config.DBDriver != DriverPostgres {
+		return nil, fmt.Errorf("read replicas are only supported with the %s driver", DriverPostgres)
+	}
+
+	cluster := &Cluster{primary: primary}
+	for i, dsn := range config.DBReplicas {
+		conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return nil, fmt.Errorf("replica %d: %w", i+1, err)
+		}
+
+		cluster.replicas = append(cluster.replicas, &replica{
+			name: fmt.Sprintf("replica %d", i+1),
+			db:   conn,
+		})
+	}
+
+	if len(cluster.replicas) > 0 {
+		cluster.CheckReplicas(context.Background())
+		log.Printf("connected to %d postgres read replicas", len(cluster.replicas))
+	}
+
+	return cluster, nil
+}
+
+// Primary returns the connection for writes and transactions.
+func (c *Cluster) Primary() *gorm.DB {
+	return c.primary
+}
+
+// Replica returns the healthy replicas in turn, or nil when none is
+// healthy and reads have to fall back to the primary.
+func (c *Cluster) Replica() *gorm.DB {
+	n := uint64(len(c.replicas))
+	start := c.next.Add(1)
+
+	for i := uint64(0); i < n; i++ {
+		r := c.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return nil
+}
+
+// CheckReplicas pings every replica, taking the ones that fail out of
+// rotation and putting back the ones that recovered.
+func (c *Cluster) CheckReplicas(ctx context.Context) {
+	for _, r := range c.replicas {
+		healthy := r.ping(ctx) == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Printf("%s is healthy, adding it to rotation", r.name)
+			} else {
+				log.Printf("%s failed its health check, removing it from rotation", r.name)
+			}
+		}
+	}
+}
+
+// MonitorReplicas runs CheckReplicas every interval until ctx is done.
+func (c *Cluster) MonitorReplicas(ctx context.Context, interval time.Duration) {
+	if len(c.replicas) == 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckReplicas(ctx)
+		}
+	}
+}
+
+func (r *replica) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Open connects to the SQL database selected by config.DBDriver.
+func Open(config *Config) (*gorm.DB, error) {
+	switch config.DBDriver {
+	case DriverPostgres:
+		return NewPostgresDB(config)
+	case DriverSQLite:
+		return NewSQLiteDB(config)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", config.DBDriver)
+	}
+}
@@ -0,0 +1,412 @@
+package db
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrationsDir is where `migrate create` writes new files, relative to the
+// repository root. It holds one directory of migrations per SQL driver, kept
+// in step by version.
+const MigrationsDir = "internal/db/migrations"
+
+// migrationDialects are the drivers with their own migration directory.
+var migrationDialects = []string{DriverPostgres, DriverSQLite}
+
+//go:embed migrations/*/*.sql
+var embeddedMigrations embed.FS
+
+// Migration is one goose-annotated SQL file.
+type Migration struct {
+	Version int64
+	Name    string
+
+	up            []string
+	down          []string
+	noTransaction bool
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of the table tracking applied versions.
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations embedded in the binary for the driver
+// db was opened with.
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(embeddedMigrations, "migrations/"+db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// it applied. Several instances starting at once take turns: the applied
+// versions are read again under the migration lock, so each migration runs
+// only once.
+func (m *Migrator) Up() ([]Migration, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	} else if len(m.pending(applied)) == 0 {
+		return []Migration{}, nil
+	}
+
+	done := []Migration{}
+	err = m.withLock(func(db *gorm.DB) error {
+		if err := m.ensureTable(db); err != nil {
+			return err
+		}
+
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.pending(applied) {
+			err := m.run(db, migration, migration.up, func(tx *gorm.DB) error {
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			done = append(done, migration)
+		}
+		return nil
+	})
+	if err != nil && m.db.Dialector.Name() == DriverSQLite {
+		// the whole run rolled back with the transaction holding the lock
+		done = []Migration{}
+	}
+
+	return done, err
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down() (*Migration, error) {
+	var migration *Migration
+	err := m.withLock(func(db *gorm.DB) error {
+		var err error
+		migration, err = m.down(db)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+func (m *Migrator) down(db *gorm.DB) (*Migration, error) {
+	var last schemaMigration
+	if err := m.ensureTable(db); err != nil {
+		return nil, err
+	}
+
+	result := db.Order("version desc").Limit(1).Find(&last)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("no migrations to roll back")
+	}
+
+	var migration *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == last.Version {
+			migration = &m.migrations[i]
+		}
+	}
+	if migration == nil {
+		return nil, fmt.Errorf("migration %d is applied but no longer embedded", last.Version)
+	}
+
+	err := m.run(db, *migration, migration.down, func(tx *gorm.DB) error {
+		return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return migration, nil
+}
+
+// Status lists every embedded migration with the time it was applied, if
+// it was.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	return db.AutoMigrate(&schemaMigration{})
+}
+
+// applied reads the applied versions, none when the table tracking them is
+// not there yet.
+func (m *Migrator) applied(db *gorm.DB) (map[int64]schemaMigration, error) {
+	applied := map[int64]schemaMigration{}
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return applied, nil
+	}
+
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) pending(applied map[int64]schemaMigration) []Migration {
+	pending := []Migration{}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// migrationLockKey identifies the Postgres advisory lock taken while
+// migrating.
+const migrationLockKey = 7361029845
+
+// withLock runs fn while holding a lock no other migrator can take at the
+// same time. Postgres takes a session advisory lock on a connection pinned
+// for fn. SQLite has no such lock, so fn runs inside one transaction, which
+// the driver opens with BEGIN IMMEDIATE and so holds the write lock until
+// it ends; the transactions of the migrations become savepoints within it.
+func (m *Migrator) withLock(fn func(db *gorm.DB) error) error {
+	if m.db.Dialector.Name() == DriverSQLite {
+		return m.db.Transaction(fn)
+	}
+
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		return fn(conn)
+	})
+}
+
+// run executes the statements and the bookkeeping write in one transaction,
+// unless the file opted out with "-- +goose NO TRANSACTION".
+func (m *Migrator) run(db *gorm.DB, migration Migration, statements []string, record func(tx *gorm.DB) error) error {
+	exec := func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	}
+
+	if migration.noTransaction {
+		return exec(db)
+	}
+	return db.Transaction(exec)
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.sql$`)
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []Migration{}
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), version)
+		}
+		seen[version] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, err := parseMigration(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		migration.Version = version
+		migration.Name = match[2]
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parseMigration splits a goose SQL file into its up and down statements.
+// Statements end with a semicolon at the end of a line, or span a
+// StatementBegin/StatementEnd block.
+func parseMigration(content string) (Migration, error) {
+	var migration Migration
+	var section *[]string
+	var buf strings.Builder
+	inBlock := false
+
+	flush := func() {
+		statement := strings.TrimSpace(buf.String())
+		buf.Reset()
+		if statement != "" && section != nil {
+			*section = append(*section, statement)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "-- +goose") {
+			switch strings.TrimSpace(strings.TrimPrefix(trimmed, "-- +goose")) {
+			case "Up":
+				flush()
+				section = &migration.up
+			case "Down":
+				flush()
+				section = &migration.down
+			case "StatementBegin":
+				flush()
+				inBlock = true
+			case "StatementEnd":
+				flush()
+				inBlock = false
+			case "NO TRANSACTION":
+				migration.noTransaction = true
+			}
+			continue
+		}
+
+		if section == nil || (!inBlock && (trimmed == "" || strings.HasPrefix(trimmed, "--"))) {
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteString("\n")
+
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return migration, err
+	}
+	if inBlock {
+		return migration, errors.New("missing -- +goose StatementEnd")
+	}
+	if migration.up == nil {
+		return migration, errors.New("missing -- +goose Up section")
+	}
+
+	return migration, nil
+}
+
+var nonWord = regexp.MustCompile(`\W+`)
+
+// CreateMigration writes an empty goose migration named after the current
+// time into the directory of every driver under dir and returns the paths.
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name cannot be empty")
+	}
+
+	fileName := fmt.Sprintf("%s_%s.sql", time.Now().UTC().Format("20060102150405"), name)
+	template := `-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+`
+
+	paths := []string{}
+	for _, dialect := range migrationDialects {
+		path := filepath.Join(dir, dialect, fileName)
+		if err := os.WriteFile(path, []byte(template), 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
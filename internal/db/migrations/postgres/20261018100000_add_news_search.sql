@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN "language" varchar(20) NOT NULL DEFAULT 'simple';
+ALTER TABLE news ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	CASE "language"
+		WHEN 'english' THEN
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce("content", '')), 'B')
+		WHEN 'indonesian' THEN
+			setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('indonesian', coalesce("content", '')), 'B')
+		ELSE
+			setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce("content", '')), 'B')
+	END
+) STORED;
+CREATE INDEX idx_news_search_vector ON news USING gin (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_news_search_vector;
+ALTER TABLE news DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE news DROP COLUMN IF EXISTS "language";
+-- +goose StatementEnd
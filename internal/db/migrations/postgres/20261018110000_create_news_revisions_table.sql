@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_revisions (
+	id bigserial NOT NULL,
+	uuid text NULL DEFAULT gen_random_uuid(),
+	news_id int8 NOT NULL,
+	revision int4 NOT NULL,
+	title varchar(255) NULL,
+	"content" text NULL,
+	status varchar(50) NULL,
+	"language" varchar(20) NULL,
+	topics text NULL,
+	created_at timestamptz NULL,
+	CONSTRAINT news_revisions_pkey PRIMARY KEY (id),
+	CONSTRAINT uni_news_revisions_news_revision UNIQUE (news_id, revision)
+);
+ALTER TABLE news_revisions ADD CONSTRAINT fk_news_revisions_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_revisions;
+-- +goose StatementEnd
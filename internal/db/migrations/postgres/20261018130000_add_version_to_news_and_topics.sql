@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN version int4 NOT NULL DEFAULT 1;
+ALTER TABLE topics ADD COLUMN version int4 NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE topics DROP COLUMN IF EXISTS version;
+ALTER TABLE news DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
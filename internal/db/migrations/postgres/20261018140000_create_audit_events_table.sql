@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE audit_events (
+	id bigserial NOT NULL,
+	uuid text NULL DEFAULT gen_random_uuid(),
+	actor varchar(255) NOT NULL,
+	"action" varchar(50) NOT NULL,
+	entity_type varchar(50) NOT NULL,
+	entity_uuid text NOT NULL,
+	request_id varchar(255) NULL,
+	"before" jsonb NULL,
+	"after" jsonb NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT audit_events_pkey PRIMARY KEY (id)
+);
+CREATE INDEX idx_audit_events_entity ON audit_events (entity_type, entity_uuid, created_at);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor, created_at);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN publish_at timestamptz NULL;
+CREATE INDEX idx_news_scheduled_publish_at ON news (publish_at) WHERE status = 'scheduled';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_news_scheduled_publish_at;
+ALTER TABLE news DROP COLUMN publish_at;
+-- +goose StatementEnd
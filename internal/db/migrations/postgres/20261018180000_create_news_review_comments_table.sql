@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_review_comments (
+	id bigserial NOT NULL,
+	uuid text NULL DEFAULT gen_random_uuid(),
+	news_id int8 NOT NULL,
+	parent_id int8 NULL,
+	author varchar(255) NULL,
+	body text NULL,
+	anchor_start int4 NULL,
+	anchor_end int4 NULL,
+	anchor_text text NULL,
+	resolved_at timestamptz NULL,
+	resolved_by varchar(255) NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	CONSTRAINT news_review_comments_pkey PRIMARY KEY (id)
+);
+ALTER TABLE news_review_comments ADD CONSTRAINT fk_news_review_comments_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE news_review_comments ADD CONSTRAINT fk_news_review_comments_parent FOREIGN KEY (parent_id) REFERENCES news_review_comments(id) ON DELETE CASCADE ON UPDATE CASCADE;
+CREATE INDEX idx_news_review_comments_news_id ON news_review_comments (news_id, created_at);
+CREATE INDEX idx_news_review_comments_unresolved ON news_review_comments (news_id) WHERE parent_id IS NULL AND resolved_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_review_comments;
+-- +goose StatementEnd
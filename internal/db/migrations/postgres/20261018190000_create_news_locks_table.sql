@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_locks (
+	news_id int8 NOT NULL,
+	holder varchar(255) NOT NULL,
+	acquired_at timestamptz NOT NULL,
+	expires_at timestamptz NOT NULL,
+	CONSTRAINT news_locks_pkey PRIMARY KEY (news_id)
+);
+ALTER TABLE news_locks ADD CONSTRAINT fk_news_locks_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_locks;
+-- +goose StatementEnd
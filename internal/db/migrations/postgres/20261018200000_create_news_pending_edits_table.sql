@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_pending_edits (
+	news_id int8 NOT NULL,
+	title varchar(255) NULL,
+	"content" text NULL,
+	"language" varchar(20) NULL,
+	topics text NULL,
+	"version" int4 NOT NULL DEFAULT 1,
+	updated_by varchar(255) NULL,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	CONSTRAINT news_pending_edits_pkey PRIMARY KEY (news_id)
+);
+ALTER TABLE news_pending_edits ADD CONSTRAINT fk_news_pending_edits_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_pending_edits;
+-- +goose StatementEnd
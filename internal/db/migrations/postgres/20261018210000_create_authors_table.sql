@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE authors (
+	id bigserial NOT NULL,
+	uuid text NULL DEFAULT gen_random_uuid(),
+	"name" varchar(255) NULL,
+	slug varchar(255) NULL,
+	bio text NULL,
+	avatar_url varchar(2048) NULL,
+	"version" int4 NOT NULL DEFAULT 1,
+	created_at timestamptz NULL,
+	updated_at timestamptz NULL,
+	deleted_at timestamptz NULL,
+	CONSTRAINT authors_pkey PRIMARY KEY (id)
+);
+CREATE INDEX idx_authors_deleted_at ON authors USING btree (deleted_at);
+-- deleted authors give their slug up
+CREATE UNIQUE INDEX uni_authors_slug ON authors USING btree (slug) WHERE deleted_at IS NULL;
+
+CREATE TABLE news_authors (
+	news_id int8 NOT NULL,
+	author_id int8 NOT NULL,
+	"position" int4 NOT NULL,
+	CONSTRAINT news_authors_pkey PRIMARY KEY (news_id, author_id)
+);
+ALTER TABLE news_authors ADD CONSTRAINT fk_news_authors_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE news_authors ADD CONSTRAINT fk_news_authors_author FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE ON UPDATE CASCADE;
+CREATE INDEX idx_news_authors_author_id ON news_authors USING btree (author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_authors;
+DROP TABLE IF EXISTS authors;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE topic_pins (
+	topic_id int8 NOT NULL,
+	news_id int8 NOT NULL,
+	"position" int4 NOT NULL,
+	expires_at timestamptz NULL,
+	created_at timestamptz NULL,
+	CONSTRAINT topic_pins_pkey PRIMARY KEY (topic_id, news_id)
+);
+ALTER TABLE topic_pins ADD CONSTRAINT fk_topic_pins_topic FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE topic_pins ADD CONSTRAINT fk_topic_pins_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE;
+CREATE INDEX idx_topic_pins_news_id ON topic_pins USING btree (news_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS topic_pins;
+-- +goose StatementEnd
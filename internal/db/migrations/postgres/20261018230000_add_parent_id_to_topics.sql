@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE topics ADD COLUMN parent_id int8 NULL;
+ALTER TABLE topics ADD CONSTRAINT fk_topics_parent FOREIGN KEY (parent_id) REFERENCES topics(id) ON DELETE SET NULL ON UPDATE CASCADE;
+CREATE INDEX idx_topics_parent_id ON topics USING btree (parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_topics_parent_id;
+ALTER TABLE topics DROP CONSTRAINT IF EXISTS fk_topics_parent;
+ALTER TABLE topics DROP COLUMN parent_id;
+-- +goose StatementEnd
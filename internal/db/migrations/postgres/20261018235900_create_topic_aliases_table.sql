@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE topic_aliases (
+	id bigserial NOT NULL,
+	uuid text NULL DEFAULT gen_random_uuid(),
+	topic_id int8 NOT NULL,
+	value varchar(255) NOT NULL,
+	created_at timestamptz NULL,
+	CONSTRAINT topic_aliases_pkey PRIMARY KEY (id),
+	CONSTRAINT uni_topic_aliases_value UNIQUE (value)
+);
+ALTER TABLE topic_aliases ADD CONSTRAINT fk_topic_aliases_topic FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE ON UPDATE CASCADE;
+CREATE INDEX idx_topic_aliases_topic_id ON topic_aliases USING btree (topic_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS topic_aliases;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE topics (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	title varchar(255) NULL,
+	value varchar(255) NULL,
+	created_at datetime NULL,
+	updated_at datetime NULL,
+	deleted_at datetime NULL,
+	CONSTRAINT uni_topics_title UNIQUE (title),
+	CONSTRAINT uni_topics_value UNIQUE (value)
+);
+CREATE INDEX idx_topics_deleted_at ON topics (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS topics;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	title varchar(255) NULL,
+	"content" text NULL,
+	status varchar(50) NULL,
+	created_at datetime NULL,
+	updated_at datetime NULL,
+	deleted_at datetime NULL
+);
+CREATE INDEX idx_news_deleted_at ON news (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- SQLite has no text search configurations, the repository falls back to
+-- matching query words with LIKE, so only the language column is added.
+ALTER TABLE news ADD COLUMN "language" varchar(20) NOT NULL DEFAULT 'simple';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE news DROP COLUMN "language";
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_revisions (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	news_id integer NOT NULL,
+	revision integer NOT NULL,
+	title varchar(255) NULL,
+	"content" text NULL,
+	status varchar(50) NULL,
+	"language" varchar(20) NULL,
+	topics text NULL,
+	created_at datetime NULL,
+	CONSTRAINT uni_news_revisions_news_revision UNIQUE (news_id, revision),
+	CONSTRAINT fk_news_revisions_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_revisions;
+-- +goose StatementEnd
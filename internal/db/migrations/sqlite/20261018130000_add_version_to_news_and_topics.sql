@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN version integer NOT NULL DEFAULT 1;
+ALTER TABLE topics ADD COLUMN version integer NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE topics DROP COLUMN version;
+ALTER TABLE news DROP COLUMN version;
+-- +goose StatementEnd
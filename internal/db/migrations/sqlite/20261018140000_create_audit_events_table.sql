@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE audit_events (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	actor varchar(255) NOT NULL,
+	"action" varchar(50) NOT NULL,
+	entity_type varchar(50) NOT NULL,
+	entity_uuid text NOT NULL,
+	request_id varchar(255) NULL,
+	"before" text NULL,
+	"after" text NULL,
+	created_at datetime NOT NULL
+);
+CREATE INDEX idx_audit_events_entity ON audit_events (entity_type, entity_uuid, created_at);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor, created_at);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN expires_at datetime NULL;
+CREATE INDEX idx_news_published_expires_at ON news (expires_at) WHERE status = 'published';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_news_published_expires_at;
+ALTER TABLE news DROP COLUMN expires_at;
+-- +goose StatementEnd
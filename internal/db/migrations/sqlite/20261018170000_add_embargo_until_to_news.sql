@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE news ADD COLUMN embargo_until datetime NULL;
+CREATE INDEX idx_news_embargo_until ON news (embargo_until) WHERE embargo_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_news_embargo_until;
+ALTER TABLE news DROP COLUMN embargo_until;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_review_comments (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	news_id integer NOT NULL,
+	parent_id integer NULL,
+	author varchar(255) NULL,
+	body text NULL,
+	anchor_start integer NULL,
+	anchor_end integer NULL,
+	anchor_text text NULL,
+	resolved_at datetime NULL,
+	resolved_by varchar(255) NULL,
+	created_at datetime NULL,
+	updated_at datetime NULL,
+	CONSTRAINT fk_news_review_comments_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT fk_news_review_comments_parent FOREIGN KEY (parent_id) REFERENCES news_review_comments(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_news_review_comments_news_id ON news_review_comments (news_id, created_at);
+CREATE INDEX idx_news_review_comments_unresolved ON news_review_comments (news_id) WHERE parent_id IS NULL AND resolved_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_review_comments;
+-- +goose StatementEnd
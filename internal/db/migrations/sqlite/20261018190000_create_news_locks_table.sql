@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_locks (
+	news_id integer NOT NULL PRIMARY KEY,
+	holder varchar(255) NOT NULL,
+	acquired_at datetime NOT NULL,
+	expires_at datetime NOT NULL,
+	CONSTRAINT fk_news_locks_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_locks;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE news_pending_edits (
+	news_id integer NOT NULL PRIMARY KEY,
+	title varchar(255) NULL,
+	"content" text NULL,
+	"language" varchar(20) NULL,
+	topics text NULL,
+	"version" integer NOT NULL DEFAULT 1,
+	updated_by varchar(255) NULL,
+	created_at datetime NULL,
+	updated_at datetime NULL,
+	CONSTRAINT fk_news_pending_edits_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_pending_edits;
+-- +goose StatementEnd
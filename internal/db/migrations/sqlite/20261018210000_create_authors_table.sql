@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE authors (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	"name" varchar(255) NULL,
+	slug varchar(255) NULL,
+	bio text NULL,
+	avatar_url varchar(2048) NULL,
+	"version" integer NOT NULL DEFAULT 1,
+	created_at datetime NULL,
+	updated_at datetime NULL,
+	deleted_at datetime NULL
+);
+CREATE INDEX idx_authors_deleted_at ON authors (deleted_at);
+-- deleted authors give their slug up
+CREATE UNIQUE INDEX uni_authors_slug ON authors (slug) WHERE deleted_at IS NULL;
+
+CREATE TABLE news_authors (
+	news_id integer NOT NULL,
+	author_id integer NOT NULL,
+	"position" integer NOT NULL,
+	CONSTRAINT news_authors_pkey PRIMARY KEY (news_id, author_id),
+	CONSTRAINT fk_news_authors_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT fk_news_authors_author FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_news_authors_author_id ON news_authors (author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS news_authors;
+DROP TABLE IF EXISTS authors;
+-- +goose StatementEnd
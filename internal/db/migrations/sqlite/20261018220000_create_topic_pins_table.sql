@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE topic_pins (
+	topic_id integer NOT NULL,
+	news_id integer NOT NULL,
+	"position" integer NOT NULL,
+	expires_at datetime NULL,
+	created_at datetime NULL,
+	CONSTRAINT topic_pins_pkey PRIMARY KEY (topic_id, news_id),
+	CONSTRAINT fk_topic_pins_topic FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT fk_topic_pins_news FOREIGN KEY (news_id) REFERENCES news(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_topic_pins_news_id ON topic_pins (news_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS topic_pins;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- no foreign key: SQLite cannot drop a column that has one, and topics
+-- with children cannot be deleted anyway
+ALTER TABLE topics ADD COLUMN parent_id integer NULL;
+CREATE INDEX idx_topics_parent_id ON topics (parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_topics_parent_id;
+ALTER TABLE topics DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE topic_aliases (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	uuid text NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+	topic_id integer NOT NULL,
+	value varchar(255) NOT NULL,
+	created_at datetime NULL,
+	CONSTRAINT uni_topic_aliases_value UNIQUE (value),
+	CONSTRAINT fk_topic_aliases_topic FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_topic_aliases_topic_id ON topic_aliases (topic_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS topic_aliases;
+-- +goose StatementEnd
@@ -4,18 +4,21 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func NewPostgresDB() (*gorm.DB, error) {
-	config, err := LoadConfig()
-	if err != nil {
-		return nil, err
-	}
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
 
+func NewPostgresDB(config *Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		config.DBHost, config.DBUser, config.DBPassword, config.DBName, config.DBPort,
@@ -28,18 +31,44 @@ func NewPostgresDB() (*gorm.DB, error) {
 
 	log.Println("connected to postgres database")
 
-	// Migrate the schema
-	// db.AutoMigrate(&entities.News{}, &entities.Topic{})
-
 	return db, nil
 }
 
 type Config struct {
+	DBDriver   string
 	DBUser     string
 	DBPassword string
 	DBName     string
 	DBHost     string
 	DBPort     string
+	// DBPath is the database file used by the sqlite driver.
+	DBPath string
+
+	// DBReplicas are the DSNs of the postgres read replicas. Health checks
+	// run every DBReplicaCheckInterval.
+	DBReplicas             []string
+	DBReplicaCheckInterval time.Duration
+
+	// Deadlines applied to each read (list/get) and write query.
+	DBReadTimeout  time.Duration
+	DBWriteTimeout time.Duration
+
+	// PublishInterval is how often scheduled news is checked for publishing.
+	PublishInterval time.Duration
+	// ExpireInterval is how often published news is checked for expiry.
+	ExpireInterval time.Duration
+	// EmbargoInterval is how often passed embargoes are lifted.
+	EmbargoInterval time.Duration
+
+	// InternalToken lets callers that send it in X-Internal-Token read news
+	// under embargo. Empty turns internal access off.
+	InternalToken string
+	// AdminToken lets callers that send it in X-Admin-Token break edit locks
+	// held by others. Empty turns admin access off.
+	AdminToken string
+
+	// EditLockTTL is how long an edit lock lasts without a heartbeat.
+	EditLockTTL time.Duration
 }
 
 func LoadConfig() (*Config, error) {
@@ -49,11 +78,62 @@ func LoadConfig() (*Config, error) {
 		log.Print("No .env file found")
 	}
 
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = DriverPostgres
+	}
+
 	return &Config{
+		DBDriver:   driver,
 		DBUser:     os.Getenv("DB_USER"),
 		DBPassword: os.Getenv("DB_PASSWORD"),
 		DBName:     os.Getenv("DB_NAME"),
 		DBHost:     os.Getenv("DB_HOST"),
 		DBPort:     os.Getenv("DB_PORT"),
+		DBPath:     os.Getenv("DB_PATH"),
+
+		DBReplicas:             listEnv("DB_REPLICAS"),
+		DBReplicaCheckInterval: durationEnv("DB_REPLICA_CHECK_INTERVAL", 5*time.Second),
+
+		DBReadTimeout:  durationEnv("DB_READ_TIMEOUT", 5*time.Second),
+		DBWriteTimeout: durationEnv("DB_WRITE_TIMEOUT", 5*time.Second),
+
+		PublishInterval: durationEnv("PUBLISH_INTERVAL", 15*time.Second),
+		ExpireInterval:  durationEnv("EXPIRE_INTERVAL", time.Minute),
+		EmbargoInterval: durationEnv("EMBARGO_INTERVAL", 15*time.Second),
+
+		InternalToken: os.Getenv("INTERNAL_TOKEN"),
+		AdminToken:    os.Getenv("ADMIN_TOKEN"),
+
+		EditLockTTL: durationEnv("EDIT_LOCK_TTL", 5*time.Minute),
 	}, nil
 }
+
+// durationEnv parses a duration such as "500ms" or "3s" from the
+// environment, falling back to def when unset or invalid.
+func durationEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("invalid %s %q, using %s", key, value, def)
+		return def
+	}
+
+	return d
+}
+
+// listEnv splits a comma separated environment variable, skipping blanks.
+func listEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
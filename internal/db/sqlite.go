@@ -0,0 +1,34 @@
+package db
+
+import (
+	"log"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const defaultSQLitePath = "news.db"
+
+// NewSQLiteDB opens the embedded SQLite database at config.DBPath, creating
+// the file when it does not exist yet.
+func NewSQLiteDB(config *Config) (*gorm.DB, error) {
+	path := config.DBPath
+	if path == "" {
+		path = defaultSQLitePath
+	}
+
+	// Foreign keys are off by default in SQLite, the cascades in the
+	// migrations rely on them. Transactions take the write lock up front so
+	// concurrent units of work wait on busy_timeout instead of failing when
+	// they upgrade from reading to writing.
+	dsn := "file:" + path + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("connected to sqlite database %s", path)
+
+	return db, nil
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+type FilterAuditRequest struct {
+	EntityType *string `json:"entity_type" validate:"omitempty,oneof=news topic author"`
+	EntityUuid *string `json:"entity_uuid"`
+	Actor      *string `json:"actor"`
+
+	// From and To bound created_at, From inclusive and To exclusive.
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+type CreateAuthorRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Slug is derived from Name when it is left empty.
+	Slug      string `json:"slug" validate:"omitempty,max=255"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+}
+
+type UpdateAuthorRequest struct {
+	Name      string `json:"name" validate:"omitempty,max=255"`
+	Slug      string `json:"slug" validate:"omitempty,max=255"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+}
+
+type AuthorUuid struct {
+	Uuid string `json:"uuid" validate:"required"`
+}
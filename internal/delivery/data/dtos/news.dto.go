@@ -1,21 +1,52 @@
 package dtos
 
+import "time"
+
 type CreateNewsRequest struct {
-	Title   string      `json:"title" validate:"required"`
-	Content string      `json:"content" validate:"required"`
-	Status  string      `json:"status" validate:"required,oneof=published draft"`
-	Topics  []TopicUuid `json:"topics"`
+	Title    string      `json:"title" validate:"required"`
+	Content  string      `json:"content" validate:"required"`
+	Status   string      `json:"status" validate:"required,oneof=draft in_review"`
+	Language string      `json:"language" validate:"omitempty,oneof=simple english indonesian"`
+	Topics   []TopicUuid `json:"topics"`
+	// Authors make up the byline, in the order given.
+	Authors []AuthorUuid `json:"authors" validate:"omitempty,dive"`
+	// EmbargoUntil hides the news from public reads until then.
+	EmbargoUntil *time.Time `json:"embargo_until"`
 }
 
 type UpdateNewsRequest struct {
-	Title   string      `json:"title"`
-	Content string      `json:"content"`
-	Status  string      `json:"status"`
-	Topics  []TopicUuid `json:"topics"`
+	Title    string      `json:"title"`
+	Content  string      `json:"content"`
+	Status   string      `json:"status" validate:"omitempty,oneof=draft in_review approved scheduled published archived deleted"`
+	Language string      `json:"language" validate:"omitempty,oneof=simple english indonesian"`
+	Topics   []TopicUuid `json:"topics"`
+	// Authors, when sent, replace the byline in the order given.
+	Authors []AuthorUuid `json:"authors" validate:"omitempty,dive"`
+}
+
+// EditPendingNewsRequest changes the working copy of published news. Empty
+// fields are left as they are; topics, when sent, replace the current ones.
+type EditPendingNewsRequest struct {
+	Title    string      `json:"title"`
+	Content  string      `json:"content"`
+	Language string      `json:"language" validate:"omitempty,oneof=simple english indonesian"`
+	Topics   []TopicUuid `json:"topics" validate:"omitempty,dive"`
 }
 
 type UpdateNewsStatus struct {
-	Status string `json:"status" validate:"required,oneof=published draft"`
+	Status string `json:"status" validate:"required,oneof=draft in_review approved scheduled published archived deleted"`
+}
+
+type ScheduleNewsRequest struct {
+	PublishAt *time.Time `json:"publish_at" validate:"required"`
+}
+
+type SetNewsExpiryRequest struct {
+	ExpiresAt *time.Time `json:"expires_at" validate:"required"`
+}
+
+type EmbargoNewsRequest struct {
+	EmbargoUntil *time.Time `json:"embargo_until" validate:"required"`
 }
 
 type TopicUuid struct {
@@ -23,7 +54,44 @@ type TopicUuid struct {
 }
 
 type FilterNewsRequest struct {
-	Title  *string `json:"title"`
-	Topic  *string `json:"topic"`
-	Status *string `json:"status"`
+	Title *string `json:"title"`
+	Topic *string `json:"topic"`
+	// IncludeDescendants widens Topic to the topics below it.
+	IncludeDescendants bool `json:"include_descendants"`
+	// TopicValues are the values Topic stands for: itself and, with
+	// IncludeDescendants, its descendants. They are filled in by the use
+	// case, never by the client.
+	TopicValues []string `json:"-"`
+	Status      *string  `json:"status"`
+	// Author is the slug of an author in the byline.
+	Author *string `json:"author"`
+	// IncludeArchived lists archived news too. Without it archived news is
+	// only listed when Status asks for it.
+	IncludeArchived bool `json:"include_archived"`
+	// VisibleAt leaves out news that is under embargo at that time. It is
+	// set for public callers, never by the client.
+	VisibleAt *time.Time `json:"-"`
+	// PinnedAt lists the news pinned to Topic first, in pin order, counting
+	// the pins that are active at that time. It is set by the use case,
+	// never by the client.
+	PinnedAt *time.Time `json:"-"`
+
+	// Query is a full-text search over title and content, stemmed with
+	// Language or, when no language is given, with every supported one.
+	Query    *string `json:"q"`
+	Language *string `json:"lang" validate:"omitempty,oneof=simple english indonesian"`
+}
+
+// BulkNewsRequest runs one action on many news items, named either by UUID
+// or by a filter that works like the one of GET /news.
+type BulkNewsRequest struct {
+	Action string             `json:"action" validate:"required,oneof=publish archive delete add_topics remove_topics"`
+	UUIDs  []string           `json:"uuids" validate:"required_without=Filter,excluded_with=Filter,omitempty,dive,required"`
+	Filter *FilterNewsRequest `json:"filter" validate:"required_without=UUIDs"`
+	// Topics are added or removed by the add_topics and remove_topics
+	// actions.
+	Topics []TopicUuid `json:"topics" validate:"required_if=Action add_topics,required_if=Action remove_topics,dive"`
+	// Mode is transaction (the default), where one failure rolls back every
+	// item, or per_item, where each item is committed on its own.
+	Mode string `json:"mode" validate:"omitempty,oneof=transaction per_item"`
 }
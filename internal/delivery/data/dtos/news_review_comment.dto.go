@@ -0,0 +1,16 @@
+package dtos
+
+type CreateNewsReviewCommentRequest struct {
+	Body string `json:"body" validate:"required"`
+	// ParentUuid makes the comment a reply in the thread of that comment.
+	ParentUuid string `json:"parent_uuid"`
+	// Anchor ties a thread to part of the content. Replies cannot have one.
+	Anchor *NewsCommentAnchor `json:"anchor"`
+}
+
+// NewsCommentAnchor selects the characters [start, end) of the news
+// content, counted in Unicode code points.
+type NewsCommentAnchor struct {
+	Start int `json:"start" validate:"min=0"`
+	End   int `json:"end" validate:"gtfield=Start"`
+}
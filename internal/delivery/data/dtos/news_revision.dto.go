@@ -0,0 +1,7 @@
+package dtos
+
+type DiffNewsRevisionsRequest struct {
+	From int    `json:"from" validate:"required,min=1"`
+	To   int    `json:"to" validate:"required,min=1"`
+	Mode string `json:"mode" validate:"omitempty,oneof=line word"`
+}
@@ -1,10 +1,44 @@
 package dtos
 
+import "time"
+
 type CreateTopicRequest struct {
 	Title string `json:"title" validate:"required,min=3,max=255"`
 	Value string `json:"value"`
+	// ParentUuid places the new topic under another one.
+	ParentUuid string `json:"parent_uuid"`
 }
 
 type UpdateTopicRequest struct {
 	Title string `json:"title"`
+	// ParentUuid moves the topic under another one when it is sent, or to
+	// the root when it is sent empty.
+	ParentUuid *string `json:"parent_uuid"`
+}
+
+// PinNewsRequest pins a news item of the topic, or moves and renews its
+// pin when it is already pinned.
+type PinNewsRequest struct {
+	NewsUuid string `json:"news_uuid" validate:"required"`
+	// Position is where the pin goes, starting at 1. Zero, or a position
+	// past the last pin, puts it last.
+	Position int `json:"position" validate:"min=0"`
+	// ExpiresAt unpins the news once it passes.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ReorderTopicPinsRequest lists every pinned news item of the topic in its
+// new order.
+type ReorderTopicPinsRequest struct {
+	News []string `json:"news" validate:"required,dive,required"`
+}
+
+// MergeTopicRequest names the topic another one is merged into.
+type MergeTopicRequest struct {
+	TargetUuid string `json:"target_uuid" validate:"required"`
+}
+
+// TopicAliasRequest sets the value of a topic alias.
+type TopicAliasRequest struct {
+	Value string `json:"value" validate:"required,max=255"`
 }
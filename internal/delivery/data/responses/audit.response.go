@@ -0,0 +1,21 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventResponse struct {
+	UUID       string          `json:"uuid"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityUuid string          `json:"entity_uuid"`
+	RequestId  string          `json:"request_id"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+	// Redacted is set when before and after were left out because they
+	// hold embargoed news.
+	Redacted bool `json:"redacted,omitempty"`
+}
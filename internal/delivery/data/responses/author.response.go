@@ -0,0 +1,11 @@
+package response
+
+type AuthorResponse struct {
+	Id        uint   `json:"id"`
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+	Version   int    `json:"version,omitempty"`
+}
@@ -1,10 +1,64 @@
 package response
 
+import "time"
+
 type NewsResponse struct {
-	Id      uint            `json:"id"`
-	UUID    string          `json:"uuid"`
-	Title   string          `json:"title"`
-	Content string          `json:"content"`
-	Status  string          `json:"status"`
-	Topics  []TopicResponse `json:"topics"`
+	Id           uint             `json:"id"`
+	UUID         string           `json:"uuid"`
+	Title        string           `json:"title"`
+	Content      string           `json:"content"`
+	Status       string           `json:"status"`
+	Language     string           `json:"language"`
+	Version      int              `json:"version"`
+	PublishAt    *time.Time       `json:"publish_at,omitempty"`
+	ExpiresAt    *time.Time       `json:"expires_at,omitempty"`
+	Embargoed    bool             `json:"embargoed"`
+	EmbargoUntil *time.Time       `json:"embargo_until,omitempty"`
+	Topics       []TopicResponse  `json:"topics"`
+	Authors      []AuthorResponse `json:"authors"`
+	PinPosition  *int             `json:"pin_position,omitempty"`
+	Score        *float64         `json:"score,omitempty"`
+	Highlights   *NewsHighlights  `json:"highlights,omitempty"`
+	DeletedAt    *time.Time       `json:"deleted_at,omitempty"`
+}
+
+// NewsTransitionsResponse lists the statuses a news item can move to next.
+type NewsTransitionsResponse struct {
+	Status      string                   `json:"status"`
+	Transitions []NewsTransitionResponse `json:"transitions"`
+}
+
+// NewsTransitionResponse is one move, with the reason it is not allowed
+// right now when a guard blocks it.
+type NewsTransitionResponse struct {
+	To      string `json:"to"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// NewsHighlights holds the search matches wrapped in <mark> tags.
+type NewsHighlights struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// BulkNewsResponse reports a bulk action item by item. Succeeded counts the
+// items that were changed or already as asked, Failed all others.
+type BulkNewsResponse struct {
+	Action    string                   `json:"action"`
+	Mode      string                   `json:"mode"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []BulkNewsResultResponse `json:"results"`
+}
+
+// BulkNewsResultResponse is the outcome for one news item: succeeded,
+// unchanged, failed, or, in transaction mode, rolled_back or skipped because
+// another item failed.
+type BulkNewsResultResponse struct {
+	UUID   string `json:"uuid"`
+	Result string `json:"result"`
+	// Version is the new version of changed news.
+	Version int    `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
 }
@@ -0,0 +1,9 @@
+package response
+
+import "time"
+
+type NewsLockResponse struct {
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
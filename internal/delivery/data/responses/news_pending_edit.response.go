@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// NewsPendingEditResponse is the working copy of published news, as it will
+// read once published.
+type NewsPendingEditResponse struct {
+	NewsUUID  string          `json:"news_uuid"`
+	Title     string          `json:"title"`
+	Content   string          `json:"content"`
+	Language  string          `json:"language"`
+	Topics    []TopicResponse `json:"topics"`
+	Version   int             `json:"version"`
+	UpdatedBy string          `json:"updated_by"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
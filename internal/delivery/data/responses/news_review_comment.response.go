@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+type NewsReviewCommentsResponse struct {
+	// Unresolved counts the open threads, which hold back publishing.
+	Unresolved int                          `json:"unresolved"`
+	Threads    []*NewsReviewCommentResponse `json:"threads"`
+}
+
+type NewsReviewCommentResponse struct {
+	UUID       string                       `json:"uuid"`
+	Author     string                       `json:"author"`
+	Body       string                       `json:"body"`
+	Anchor     *NewsCommentAnchorResponse   `json:"anchor,omitempty"`
+	Resolved   bool                         `json:"resolved"`
+	ResolvedAt *time.Time                   `json:"resolved_at,omitempty"`
+	ResolvedBy string                       `json:"resolved_by,omitempty"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	Replies    []*NewsReviewCommentResponse `json:"replies"`
+}
+
+type NewsCommentAnchorResponse struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+	// Outdated is true once the anchored characters of the content no longer
+	// read Text.
+	Outdated bool `json:"outdated"`
+}
@@ -0,0 +1,34 @@
+package response
+
+import (
+	"news-topic-api/common"
+	"time"
+)
+
+type NewsRevisionResponse struct {
+	UUID      string          `json:"uuid"`
+	Revision  int             `json:"revision"`
+	Title     string          `json:"title"`
+	Content   string          `json:"content"`
+	Status    string          `json:"status"`
+	Language  string          `json:"language"`
+	Topics    []TopicResponse `json:"topics"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type NewsRevisionDiffResponse struct {
+	From          int                `json:"from"`
+	To            int                `json:"to"`
+	Mode          string             `json:"mode"`
+	Title         []common.DiffChunk `json:"title"`
+	Content       []common.DiffChunk `json:"content"`
+	Status        *ValueChange       `json:"status,omitempty"`
+	Language      *ValueChange       `json:"language,omitempty"`
+	TopicsAdded   []TopicResponse    `json:"topics_added"`
+	TopicsRemoved []TopicResponse    `json:"topics_removed"`
+}
+
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
@@ -1,8 +1,57 @@
 package response
 
+import "time"
+
 type TopicResponse struct {
-	Id    uint   `json:"id"`
+	Id         uint    `json:"id"`
+	UUID       string  `json:"uuid"`
+	Title      string  `json:"title"`
+	Value      string  `json:"value"`
+	Version    int     `json:"version,omitempty"`
+	ParentUuid *string `json:"parent_uuid,omitempty"`
+	// Path is the breadcrumb from the root topic down to this one. It is
+	// only filled by the topic endpoints.
+	Path      []TopicPathResponse `json:"path,omitempty"`
+	DeletedAt *time.Time          `json:"deleted_at,omitempty"`
+}
+
+// TopicPathResponse is one step of a topic breadcrumb.
+type TopicPathResponse struct {
 	UUID  string `json:"uuid"`
 	Title string `json:"title"`
 	Value string `json:"value"`
 }
+
+// TopicTreeResponse is a topic with the topics below it.
+type TopicTreeResponse struct {
+	Id       uint                 `json:"id"`
+	UUID     string               `json:"uuid"`
+	Title    string               `json:"title"`
+	Value    string               `json:"value"`
+	Children []*TopicTreeResponse `json:"children"`
+}
+
+// TopicAliasResponse is another value a topic answers to.
+type TopicAliasResponse struct {
+	UUID      string    `json:"uuid"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TopicMergeResponse is the topic left after a merge.
+type TopicMergeResponse struct {
+	Topic *TopicResponse `json:"topic"`
+	// NewsMoved counts the news that gained the topic. News that already
+	// had both topics are not counted.
+	NewsMoved int64 `json:"news_moved"`
+	// Alias is the value of the merged topic, which now resolves to Topic.
+	Alias string `json:"alias,omitempty"`
+}
+
+// TopicPinResponse is a news item pinned to a topic.
+type TopicPinResponse struct {
+	Position  int          `json:"position"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	PinnedAt  time.Time    `json:"pinned_at"`
+	News      NewsResponse `json:"news"`
+}
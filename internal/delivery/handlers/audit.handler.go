@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type AuditHandler struct {
+	AuditUseCase usecase.AuditUseCase
+}
+
+func NewAuditHandler(auditUseCase usecase.AuditUseCase) *AuditHandler {
+	return &AuditHandler{AuditUseCase: auditUseCase}
+}
+
+// GetAuditEvents godoc
+// @Summary Get audit events
+// @Description Get the recorded news and topic changes, newest first. For callers without X-Internal-Token, events holding embargoed news come without before and after, flagged as redacted.
+// @Tags Audit
+// @Produce  json
+// @Param per_page query int false "Number of events per page" default(20)
+// @Param page query int false "Current page number" default(1)
+// @Param entity_type query string false "Only events of this entity type" Enums(news, topic, author)
+// @Param entity_uuid query string false "Only events of this news item or topic"
+// @Param actor query string false "Only events made by this actor"
+// @Param from query string false "Only events at or after this RFC 3339 time"
+// @Param to query string false "Only events before this RFC 3339 time"
+// @Success 200 {array} response.AuditEventResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	per_page := 20
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	filter := &dtos.FilterAuditRequest{}
+
+	entityType := r.URL.Query().Get("entity_type")
+	if entityType != "" {
+		filter.EntityType = &entityType
+	}
+
+	entityUuid := r.URL.Query().Get("entity_uuid")
+	if entityUuid != "" {
+		filter.EntityUuid = &entityUuid
+	}
+
+	actor := r.URL.Query().Get("actor")
+	if actor != "" {
+		filter.Actor = &actor
+	}
+
+	var err error
+	if filter.From, err = timeParam(r, "from"); err != nil {
+		badRequest(w, err)
+		return
+	}
+	if filter.To, err = timeParam(r, "to"); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	events, totalItems, err := h.AuditUseCase.GetEvents(r.Context(), pagination, filter)
+	if err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			badRequest(w, err)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    events,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(events)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// timeParam parses an optional RFC 3339 query parameter.
+func timeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New(name + " must be an RFC 3339 time such as 2006-01-02T15:04:05Z")
+	}
+
+	return &t, nil
+}
+
+func badRequest(w http.ResponseWriter, err error) {
+	errRes := response.ErrorResponse{
+		Code:    http.StatusBadRequest,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, http.StatusBadRequest, &errRes)
+}
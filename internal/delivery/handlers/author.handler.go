@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type AuthorHandler struct {
+	AuthorUseCase usecase.AuthorUseCase
+	NewsUseCase   usecase.NewsUseCase
+}
+
+func NewAuthorHandler(authorUseCase usecase.AuthorUseCase, newsUseCase usecase.NewsUseCase) *AuthorHandler {
+	return &AuthorHandler{AuthorUseCase: authorUseCase, NewsUseCase: newsUseCase}
+}
+
+// GetAuthors godoc
+// @Summary Get all authors
+// @Description Get all authors, ordered by name
+// @Tags Authors
+// @Produce  json
+// @Param per_page query int false "Number of authors per page" default(5)
+// @Param page query int false "Current page number" default(1)
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors [get]
+func (h *AuthorHandler) GetAuthors(w http.ResponseWriter, r *http.Request) {
+	per_page := 5
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	authors, totalItems, err := h.AuthorUseCase.GetAllAuthors(r.Context(), pagination)
+	if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    authors,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(authors)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetAuthor godoc
+// @Summary Get author by uuid
+// @Description Get author by uuid
+// @Tags Authors
+// @Produce  json
+// @Param uuid path string true "Author UUID"
+// @Success 200 {object} response.AuthorResponse
+// @Header 200 {string} ETag "Current version, send it back as If-Match when writing"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors/{uuid} [get]
+func (h *AuthorHandler) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	author, err := h.AuthorUseCase.GetByUuid(r.Context(), uuid)
+	if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(author.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    author,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetAuthorNews godoc
+// @Summary Get news by an author
+// @Description Get the news with the author in its byline, newest first. Works like GET /news?author={slug}.
+// @Tags Authors
+// @Produce  json
+// @Param uuid path string true "Author UUID"
+// @Param per_page query int false "Number of news per page" default(5)
+// @Param page query int false "Current page number" default(1)
+// @Param status query string false "Filter news by status"
+// @Param include_archived query bool false "Also list archived news"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors/{uuid}/news [get]
+func (h *AuthorHandler) GetAuthorNews(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	author, err := h.AuthorUseCase.GetByUuid(r.Context(), uuid)
+	if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	per_page := 5
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	filter := &dtos.FilterNewsRequest{Author: &author.Slug}
+
+	status := r.URL.Query().Get("status")
+	if status != "" {
+		filter.Status = &status
+	}
+
+	// anything ParseBool rejects leaves archived news out
+	filter.IncludeArchived, _ = strconv.ParseBool(r.URL.Query().Get("include_archived"))
+
+	news, totalItems, err := h.NewsUseCase.GetAllNews(r.Context(), pagination, filter)
+	if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    news,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(news)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// CreateAuthor godoc
+// @Summary Create a new author
+// @Description Create an author. The slug is derived from the name when it is left out.
+// @Tags Authors
+// @Accept  json
+// @Produce  json
+// @Param author body dtos.CreateAuthorRequest true "Create Author Request"
+// @Success 201 {object} response.AuthorResponse
+// @Header 201 {string} ETag "Current version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 409 {object} response.ErrorResponse "Slug taken"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors [post]
+func (h *AuthorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateAuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	author, err := h.AuthorUseCase.CreateAuthor(r.Context(), req)
+	if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(author.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusCreated,
+		Message: "Author created successfully",
+		Data:    author,
+	}
+
+	response.NewResponseSuccess(w, http.StatusCreated, webResponse)
+}
+
+// UpdateAuthor godoc
+// @Summary Update author
+// @Description Update the fields that are sent, leaving the others as they are
+// @Tags Authors
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "Author UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Param author body dtos.UpdateAuthorRequest true "Update Author Request"
+// @Success 200 {object} response.AuthorResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Slug taken"
+// @Failure 412 {object} response.Response "Changed since it was read, the current author is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors/{uuid} [put]
+func (h *AuthorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtos.UpdateAuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	author, err := h.AuthorUseCase.UpdateByUuid(r.Context(), uuid, version, req)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.staleAuthor(w, r, uuid, err)
+		return
+	} else if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(author.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Author updated successfully",
+		Data:    author,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// DeleteAuthor godoc
+// @Summary Delete author
+// @Description Delete an author and take them out of every byline
+// @Tags Authors
+// @Produce  json
+// @Param uuid path string true "Author UUID"
+// @Param If-Match header string true "ETag of the version being deleted"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current author is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /authors/{uuid} [delete]
+func (h *AuthorHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.AuthorUseCase.DeleteByUuid(r.Context(), uuid, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.staleAuthor(w, r, uuid, err)
+		return
+	} else if err != nil {
+		authorError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Author deleted successfully",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// staleAuthor answers 412 with the current author, like staleNews.
+func (h *AuthorHandler) staleAuthor(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	webResponse := response.Response{
+		Code:    http.StatusPreconditionFailed,
+		Message: err.Error(),
+	}
+
+	if current, getErr := h.AuthorUseCase.GetByUuid(r.Context(), uuid); getErr == nil {
+		w.Header().Set("ETag", common.ETag(current.Version))
+		webResponse.Data = current
+	}
+
+	response.NewResponseSuccess(w, http.StatusPreconditionFailed, webResponse)
+}
+
+// authorError maps a missing author to 404, invalid input to 400 and a
+// taken slug to 409.
+func authorError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if err.Error() == "author not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrInvalidAuthorSlug) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrAuthorSlugTaken) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -2,13 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"news-topic-api/common"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 
 	"news-topic-api/internal/delivery/data/dtos"
 	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
 	"news-topic-api/internal/usecase"
 )
 
@@ -22,14 +27,20 @@ func NewNewsHandler(newsUseCase usecase.NewsUseCase) *NewsHandler {
 
 // GetAllNews godoc
 // @Summary Get all news
-// @Description Get all news with pagination
+// @Description Get all news with offset pagination, or with cursor pagination when a cursor parameter is sent (empty for the first page)
 // @Tags News
 // @Produce  json
 // @Param per_page query int false "Number of news per page" default(5)
 // @Param page query int false "Current page number" default(1)
+// @Param cursor query string false "Opaque cursor from meta.cursor.next_cursor; send it empty to start cursor pagination"
 // @Param filter query string false "Filter news by title"
 // @Param topic query string false "Filter news by topic"
+// @Param include_descendants query bool false "With topic, also list news of its descendant topics"
+// @Param author query string false "Filter news by author slug"
 // @Param status query string false "Filter news by status"
+// @Param include_archived query bool false "Also list archived news, which are left out unless status asks for them"
+// @Param q query string false "Full-text search over title and content, ranked by relevance"
+// @Param lang query string false "Text search language for q" Enums(simple, english, indonesian)
 // @Success 200 {array} response.Response
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
@@ -41,10 +52,23 @@ func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
 	pp, p := common.ExtractPaginationParams(r, per_page, page)
 	offset := (p - 1) * pp
 
+	keyset, cursor, err := common.ExtractCursorParam(r)
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusBadRequest, &errRes)
+		return
+	}
+
 	pagination := &common.Pagination{
 		Limit:  pp,
 		Offset: offset,
 		Page:   p,
+		Keyset: keyset,
+		Cursor: cursor,
 	}
 
 	filter := &dtos.FilterNewsRequest{}
@@ -59,18 +83,52 @@ func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
 		filter.Topic = &topic
 	}
 
+	author := r.URL.Query().Get("author")
+	if author != "" {
+		filter.Author = &author
+	}
+
 	status := r.URL.Query().Get("status")
 	if status != "" {
 		filter.Status = &status
 	}
 
-	news, totalItems, err := h.NewsUseCase.GetAllNews(pagination, filter)
+	// anything ParseBool rejects leaves archived news out
+	filter.IncludeArchived, _ = strconv.ParseBool(r.URL.Query().Get("include_archived"))
+	filter.IncludeDescendants, _ = strconv.ParseBool(r.URL.Query().Get("include_descendants"))
+
+	q := r.URL.Query().Get("q")
+	if q != "" {
+		filter.Query = &q
+	}
+
+	lang := r.URL.Query().Get("lang")
+	if lang != "" {
+		filter.Language = &lang
+	}
+
+	news, totalItems, err := h.NewsUseCase.GetAllNews(r.Context(), pagination, filter)
 	if err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) || errors.Is(err, common.ErrInvalidPagination) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusBadRequest, &errRes)
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	meta := common.NewMeta(totalItems, pp, page, offset, len(news))
+	meta := common.NewMeta(totalItems, pp, p, offset, len(news))
+	if keyset {
+		meta = common.NewCursorMeta(pp, cursor, pagination.NextCursor)
+	}
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "Data Found",
@@ -88,13 +146,14 @@ func (h *NewsHandler) GetNews(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param uuid path string true "News UUID"
 // @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "Current version, send it back as If-Match when writing"
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /news/{uuid} [get]
 func (h *NewsHandler) GetNewsByUuid(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
-	news, err := h.NewsUseCase.GetByUuid(uuid)
+	news, err := h.NewsUseCase.GetByUuid(r.Context(), uuid)
 	if err != nil {
 		errRes := response.ErrorResponse{
 			Code:    http.StatusForbidden,
@@ -105,6 +164,8 @@ func (h *NewsHandler) GetNewsByUuid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(news.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "Data Found",
@@ -138,7 +199,7 @@ func (h *NewsHandler) CreateNews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newsResponse, err := h.NewsUseCase.CreateNews(createNewsRequest)
+	newsResponse, err := h.NewsUseCase.CreateNews(r.Context(), createNewsRequest)
 	if err != nil {
 		errRes := response.ErrorResponse{
 			Code:    http.StatusForbidden,
@@ -149,34 +210,69 @@ func (h *NewsHandler) CreateNews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
 	response.NewResponseSuccess(w, http.StatusOK, newsResponse)
 }
 
 // UpdateNews godoc
 // @Summary Update news by UUID
-// @Description Update an existing news item by its UUID
+// @Description Update an existing news item by its UUID. Lock it with POST /news/{uuid}/lock first.
 // @Tags News
 // @Accept json
 // @Produce json
 // @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
 // @Param news body dtos.UpdateNewsRequest true "News data"
 // @Success 200 {object} response.Response
+// @Header 200 {string} ETag "New version"
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Status change not allowed"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /news/{uuid} [put]
 func (h *NewsHandler) UpdateNews(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var newsDto dtos.UpdateNewsRequest
 	if err := json.NewDecoder(r.Body).Decode(&newsDto); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	updatedNews, err := h.NewsUseCase.UpdateByUuid(uuid, newsDto)
+	updatedNews, err := h.NewsUseCase.UpdateByUuid(r.Context(), uuid, version, newsDto)
 	if err != nil {
-		if err.Error() == "invalid status" {
+		if errors.Is(err, common.ErrStaleVersion) {
+			staleNews(w, r, h.NewsUseCase, uuid, err)
+		} else if errors.Is(err, usecase.ErrNewsLocked) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusLocked,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusLocked, &errRes)
+		} else if errors.Is(err, usecase.ErrEditLockRequired) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusPreconditionRequired,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusPreconditionRequired, &errRes)
+		} else if errors.Is(err, entities.ErrInvalidTransition) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusConflict,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusConflict, &errRes)
+		} else if err.Error() == "invalid status" {
 			errRes := response.ErrorResponse{
 				Code:    http.StatusBadRequest,
 				Message: err.Error(),
@@ -201,6 +297,8 @@ func (h *NewsHandler) UpdateNews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(updatedNews.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "News updated successfully",
@@ -216,16 +314,34 @@ func (h *NewsHandler) UpdateNews(w http.ResponseWriter, r *http.Request) {
 // @Tags News
 // @Accept json
 // @Produce json
+// @Param If-Match header string true "ETag of the version being deleted"
 // @Success 200 {object} response.NewsResponse
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Status change not allowed"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /news [delete]
 func (h *NewsHandler) DeleteNews(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
-	if err := h.NewsUseCase.DeleteByUuid(uuid); err != nil {
-		if err.Error() == "news is already deleted" {
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.NewsUseCase.DeleteByUuid(r.Context(), uuid, version); err != nil {
+		if errors.Is(err, common.ErrStaleVersion) {
+			staleNews(w, r, h.NewsUseCase, uuid, err)
+		} else if errors.Is(err, entities.ErrInvalidTransition) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusConflict,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusConflict, &errRes)
+		} else if err.Error() == "news is already deleted" {
 			errRes := response.ErrorResponse{
 				Code:    http.StatusBadRequest,
 				Message: err.Error(),
@@ -260,31 +376,51 @@ func (h *NewsHandler) DeleteNews(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
 // @Param news body dtos.UpdateNewsStatus true "News data"
 // @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Status change not allowed"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
-// @Router /news/{uuid}/status [put]
+// @Router /news/status/{uuid} [put]
 func (h *NewsHandler) UpdateNewsStatus(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var newsDto dtos.UpdateNewsStatus
 	if err := json.NewDecoder(r.Body).Decode(&newsDto); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	updatedNews, err := h.NewsUseCase.UpdateNewsStatus(uuid, newsDto)
+	updatedNews, err := h.NewsUseCase.UpdateNewsStatus(r.Context(), uuid, version, newsDto)
 	if err != nil {
-		if err.Error() == "news is already in the desired status" {
+		var validationErrs validator.ValidationErrors
+		if errors.Is(err, common.ErrStaleVersion) {
+			staleNews(w, r, h.NewsUseCase, uuid, err)
+		} else if errors.Is(err, entities.ErrInvalidTransition) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusConflict,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusConflict, &errRes)
+		} else if errors.As(err, &validationErrs) || err.Error() == "news is already in the desired status" {
 			errRes := response.ErrorResponse{
 				Code:    http.StatusBadRequest,
 				Message: err.Error(),
 			}
 
 			response.NewResponseError(w, http.StatusBadRequest, &errRes)
-		} else if err.Error() == "news not found" {
+		} else if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "news not found" {
 			errRes := response.ErrorResponse{
 				Code:    http.StatusNotFound,
 				Message: err.Error(),
@@ -302,6 +438,8 @@ func (h *NewsHandler) UpdateNewsStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(updatedNews.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "News status updated successfully",
@@ -310,3 +448,194 @@ func (h *NewsHandler) UpdateNewsStatus(w http.ResponseWriter, r *http.Request) {
 
 	response.NewResponseSuccess(w, http.StatusOK, webResponse)
 }
+
+// GetNewsTransitions godoc
+// @Summary Get news status transitions
+// @Description List the statuses a news item can move to next. Moves blocked by a guard are listed with allowed false and the reason.
+// @Tags News
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.NewsTransitionsResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/transitions [get]
+func (h *NewsHandler) GetNewsTransitions(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	transitions, err := h.NewsUseCase.GetTransitions(r.Context(), uuid)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = http.StatusNotFound
+		}
+
+		errRes := response.ErrorResponse{
+			Code:    code,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, code, &errRes)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    transitions,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetTrashedNews godoc
+// @Summary Get deleted news
+// @Description Get soft-deleted news, most recently deleted first
+// @Tags News Trash
+// @Produce  json
+// @Param per_page query int false "Number of news per page" default(5)
+// @Param page query int false "Current page number" default(1)
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/trash [get]
+func (h *NewsHandler) GetTrashedNews(w http.ResponseWriter, r *http.Request) {
+	per_page := 5
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	news, totalItems, err := h.NewsUseCase.GetTrashedNews(r.Context(), pagination)
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusInternalServerError, &errRes)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    news,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(news)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// RestoreNews godoc
+// @Summary Restore deleted news
+// @Description Take a news item out of the trash. It is restored as a draft.
+// @Tags News Trash
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.NewsResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/trash/{uuid}/restore [post]
+func (h *NewsHandler) RestoreNews(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	restoredNews, err := h.NewsUseCase.RestoreByUuid(r.Context(), uuid)
+	if err != nil {
+		trashError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "News restored successfully",
+		Data:    restoredNews,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// PurgeNews godoc
+// @Summary Permanently delete news
+// @Description Permanently delete a news item from the trash, together with its revisions
+// @Tags News Trash
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/trash/{uuid} [delete]
+func (h *NewsHandler) PurgeNews(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	if err := h.NewsUseCase.PurgeByUuid(r.Context(), uuid); err != nil {
+		trashError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "News permanently deleted",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// trashError answers 404 when the item is not in the trash.
+func trashError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "topic not found" {
+		code = http.StatusNotFound
+	} else if errors.Is(err, entities.ErrInvalidTransition) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
+
+// staleNews answers 412 with the current news, so the client can reapply its
+// change on top of it and retry with the new ETag.
+func staleNews(w http.ResponseWriter, r *http.Request, newsUseCase usecase.NewsUseCase, uuid string, err error) {
+	webResponse := response.Response{
+		Code:    http.StatusPreconditionFailed,
+		Message: err.Error(),
+	}
+
+	if current, getErr := newsUseCase.GetByUuid(r.Context(), uuid); getErr == nil {
+		w.Header().Set("ETag", common.ETag(current.Version))
+		webResponse.Data = current
+	}
+
+	response.NewResponseSuccess(w, http.StatusPreconditionFailed, webResponse)
+}
+
+// versionFromIfMatch reads the version a write is based on. When If-Match is
+// missing (428) or malformed (400) it writes the error and returns false.
+func versionFromIfMatch(w http.ResponseWriter, r *http.Request) (int, bool) {
+	version, err := common.ExtractIfMatch(r)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, common.ErrIfMatchRequired) {
+			code = http.StatusPreconditionRequired
+		}
+
+		errRes := response.ErrorResponse{
+			Code:    code,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, code, &errRes)
+		return 0, false
+	}
+
+	return version, true
+}
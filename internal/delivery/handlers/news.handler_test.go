@@ -0,0 +1,177 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/db"
+	"news-topic-api/internal/repositories"
+	"news-topic-api/internal/routes"
+)
+
+// apiResponse is the envelope every endpoint answers with.
+type apiResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		UUID    string `json:"uuid"`
+		Title   string `json:"title"`
+		Version int    `json:"version"`
+	} `json:"data"`
+}
+
+type testServer struct {
+	t       *testing.T
+	handler http.Handler
+}
+
+func newTestServer(t *testing.T) *testServer {
+	return serverFor(t, repositories.NewRepositoriesMemory(repositories.NewMemoryStore()))
+}
+
+func serverFor(t *testing.T, repos *repositories.Repositories) *testServer {
+	return &testServer{t: t, handler: routes.InitRoutes(repos, routes.Options{EditLockTTL: time.Minute})}
+}
+
+// eachTestServer runs test against a server on a fresh memory store and
+// one on a fresh, migrated SQLite database.
+func eachTestServer(t *testing.T, test func(t *testing.T, s *testServer)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, newTestServer(t))
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		conn, err := db.NewSQLiteDB(&db.Config{DBPath: filepath.Join(t.TempDir(), "test.db")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn = conn.Session(&gorm.Session{Logger: logger.Discard})
+
+		sqlDB, err := conn.DB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		migrator, err := db.NewMigrator(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := migrator.Up(); err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, serverFor(t, repositories.NewRepositoriesGorm(conn, nil, repositories.Timeouts{})))
+	})
+}
+
+// do sends a request as the actor "editor", with If-Match when ifMatch is
+// not empty.
+func (s *testServer) do(method, path, body, ifMatch string) (*httptest.ResponseRecorder, apiResponse) {
+	s.t.Helper()
+
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(common.ActorHeader, "editor")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	var res apiResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		s.t.Fatalf("%s %s: decoding %q: %v", method, path, rec.Body.String(), err)
+	}
+	return rec, res
+}
+
+// created returns the item a create call answered with. News is answered
+// without the envelope.
+func (s *testServer) created(method, path, body string) (uuid string, version int) {
+	s.t.Helper()
+
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+	req.Header.Set(common.ActorHeader, "editor")
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK && rec.Code != http.StatusCreated {
+		s.t.Fatalf("%s %s: got %d %s", method, path, rec.Code, rec.Body.String())
+	}
+
+	var item struct {
+		UUID    string `json:"uuid"`
+		Version int    `json:"version"`
+		Data    *struct {
+			UUID    string `json:"uuid"`
+			Version int    `json:"version"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &item); err != nil {
+		s.t.Fatal(err)
+	}
+	if item.Data != nil {
+		return item.Data.UUID, item.Data.Version
+	}
+	return item.UUID, item.Version
+}
+
+func TestUpdateNewsWithStaleIfMatch(t *testing.T) {
+	s := newTestServer(t)
+
+	topicUuid, _ := s.created(http.MethodPost, "/topics", `{"title":"Sport","value":"sport"}`)
+	uuid, version := s.created(http.MethodPost, "/news", `{"title":"Final tonight","content":"c","status":"draft","language":"english","topics":[{"uuid":"`+topicUuid+`"}]}`)
+	s.created(http.MethodPost, "/news/"+uuid+"/lock", "")
+
+	rec, _ := s.do(http.MethodPut, "/news/"+uuid, `{"title":"Final postponed"}`, "")
+	if rec.Code != http.StatusPreconditionRequired {
+		t.Errorf("without If-Match: got %d, want 428", rec.Code)
+	}
+
+	rec, _ = s.do(http.MethodPut, "/news/"+uuid, `{"title":"Final postponed"}`, common.ETag(version))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first write: got %d %s", rec.Code, rec.Body.String())
+	}
+	current := rec.Header().Get("ETag")
+
+	// a second editor still holding the first version
+	rec, res := s.do(http.MethodPut, "/news/"+uuid, `{"title":"Final cancelled"}`, common.ETag(version))
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("stale write: got %d %s, want 412", rec.Code, rec.Body.String())
+	}
+	if res.Data.Title != "Final postponed" || res.Data.Version != version+1 {
+		t.Errorf("412 body holds %q version %d, want the current state", res.Data.Title, res.Data.Version)
+	}
+	if got := rec.Header().Get("ETag"); got != current {
+		t.Errorf("412 ETag is %s, want %s", got, current)
+	}
+}
+
+func TestUpdateTopicWithStaleIfMatch(t *testing.T) {
+	s := newTestServer(t)
+
+	uuid, version := s.created(http.MethodPost, "/topics", `{"title":"Sport","value":"sport"}`)
+
+	rec, _ := s.do(http.MethodPut, "/topics/"+uuid, `{"title":"Sports"}`, common.ETag(version))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first write: got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec, res := s.do(http.MethodPut, "/topics/"+uuid, `{"title":"Athletics"}`, common.ETag(version))
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("stale write: got %d %s, want 412", rec.Code, rec.Body.String())
+	}
+	if res.Data.Title != "Sports" || res.Data.Version != version+1 {
+		t.Errorf("412 body holds %q version %d, want the current state", res.Data.Title, res.Data.Version)
+	}
+}
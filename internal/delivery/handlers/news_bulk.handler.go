@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsBulkHandler struct {
+	NewsBulkUseCase usecase.NewsBulkUseCase
+}
+
+func NewNewsBulkHandler(newsBulkUseCase usecase.NewsBulkUseCase) *NewsBulkHandler {
+	return &NewsBulkHandler{NewsBulkUseCase: newsBulkUseCase}
+}
+
+// BulkNews godoc
+// @Summary Run an action on many news items
+// @Description Publish, archive, delete, add topics to or remove topics from up to 500 news items, named by UUID or matched by a filter like the one of GET /news. In transaction mode (the default) one failing item rolls back all others; in per_item mode each item is committed on its own. Items do not need If-Match, their current version is used.
+// @Tags News
+// @Accept json
+// @Produce json
+// @Param bulk body dtos.BulkNewsRequest true "Action and items"
+// @Success 200 {object} response.BulkNewsResponse "Every item succeeded"
+// @Success 207 {object} response.BulkNewsResponse "Some items failed, see results"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/bulk [post]
+func (h *NewsBulkHandler) BulkNews(w http.ResponseWriter, r *http.Request) {
+	var bulkDto dtos.BulkNewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&bulkDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	bulkResponse, err := h.NewsBulkUseCase.Bulk(r.Context(), bulkDto)
+	if err != nil {
+		code := http.StatusInternalServerError
+
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrBulkTooLarge) || err.Error() == "topic not found" {
+			code = http.StatusBadRequest
+		}
+
+		errRes := response.ErrorResponse{
+			Code:    code,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, code, &errRes)
+		return
+	}
+
+	code := http.StatusOK
+	message := "Bulk action completed successfully"
+	if bulkResponse.Failed > 0 {
+		code = http.StatusMultiStatus
+		message = "Bulk action completed with failures"
+	}
+
+	webResponse := response.Response{
+		Code:    code,
+		Message: message,
+		Data:    bulkResponse,
+	}
+
+	response.NewResponseSuccess(w, code, webResponse)
+}
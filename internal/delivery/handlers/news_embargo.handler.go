@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsEmbargoHandler struct {
+	NewsEmbargoUseCase usecase.NewsEmbargoUseCase
+	NewsUseCase        usecase.NewsUseCase
+}
+
+func NewNewsEmbargoHandler(newsEmbargoUseCase usecase.NewsEmbargoUseCase, newsUseCase usecase.NewsUseCase) *NewsEmbargoHandler {
+	return &NewsEmbargoHandler{NewsEmbargoUseCase: newsEmbargoUseCase, NewsUseCase: newsUseCase}
+}
+
+// SetEmbargo godoc
+// @Summary Embargo news
+// @Description Hide news from public reads until embargo_until. Only callers sending X-Internal-Token can embargo news, and they still see it, flagged as embargoed.
+// @Tags News Embargo
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Param embargo body dtos.EmbargoNewsRequest true "Embargo end"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 403 {object} response.ErrorResponse "X-Internal-Token missing"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/embargo [put]
+func (h *NewsEmbargoHandler) SetEmbargo(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var embargoDto dtos.EmbargoNewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&embargoDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	newsResponse, err := h.NewsEmbargoUseCase.SetEmbargo(r.Context(), uuid, version, embargoDto)
+	if err != nil {
+		h.embargoError(w, r, uuid, err)
+		return
+	}
+
+	h.writeNews(w, "Embargo set successfully", newsResponse)
+}
+
+// LiftEmbargo godoc
+// @Summary Lift a news embargo
+// @Description End the embargo now instead of at embargo_until. Needs X-Internal-Token.
+// @Tags News Embargo
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 403 {object} response.ErrorResponse "X-Internal-Token missing"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not under embargo"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/embargo [delete]
+func (h *NewsEmbargoHandler) LiftEmbargo(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsEmbargoUseCase.LiftEmbargo(r.Context(), uuid, version)
+	if err != nil {
+		h.embargoError(w, r, uuid, err)
+		return
+	}
+
+	h.writeNews(w, "Embargo lifted successfully", newsResponse)
+}
+
+func (h *NewsEmbargoHandler) writeNews(w http.ResponseWriter, message string, newsResponse *response.NewsResponse) {
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+func (h *NewsEmbargoHandler) embargoError(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	if errors.Is(err, common.ErrStaleVersion) {
+		staleNews(w, r, h.NewsUseCase, uuid, err)
+		return
+	}
+
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrEmbargoNotInFuture) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrEmbargoInternalOnly) {
+		code = http.StatusForbidden
+	} else if errors.Is(err, usecase.ErrNewsNotEmbargoed) {
+		code = http.StatusConflict
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		code = http.StatusNotFound
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsExpiryHandler struct {
+	NewsExpiryUseCase usecase.NewsExpiryUseCase
+	NewsUseCase       usecase.NewsUseCase
+}
+
+func NewNewsExpiryHandler(newsExpiryUseCase usecase.NewsExpiryUseCase, newsUseCase usecase.NewsUseCase) *NewsExpiryHandler {
+	return &NewsExpiryHandler{NewsExpiryUseCase: newsExpiryUseCase, NewsUseCase: newsUseCase}
+}
+
+// SetExpiry godoc
+// @Summary Set news expiry
+// @Description Set when news is archived. Published news is archived by the expirer once expires_at has passed.
+// @Tags News Expiry
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Param expiry body dtos.SetNewsExpiryRequest true "Expiry time"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/expiry [put]
+func (h *NewsExpiryHandler) SetExpiry(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var expiryDto dtos.SetNewsExpiryRequest
+	if err := json.NewDecoder(r.Body).Decode(&expiryDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	newsResponse, err := h.NewsExpiryUseCase.SetExpiry(r.Context(), uuid, version, expiryDto)
+	if err != nil {
+		h.expiryError(w, r, uuid, err)
+		return
+	}
+
+	h.writeNews(w, "Expiry set successfully", newsResponse)
+}
+
+// ClearExpiry godoc
+// @Summary Clear news expiry
+// @Description Keep news published until it is archived by hand
+// @Tags News Expiry
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/expiry [delete]
+func (h *NewsExpiryHandler) ClearExpiry(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsExpiryUseCase.ClearExpiry(r.Context(), uuid, version)
+	if err != nil {
+		h.expiryError(w, r, uuid, err)
+		return
+	}
+
+	h.writeNews(w, "Expiry cleared successfully", newsResponse)
+}
+
+// Unarchive godoc
+// @Summary Unarchive news
+// @Description Publish archived news again. An expiry time that has already passed is cleared.
+// @Tags News Expiry
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not archived"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/unarchive [post]
+func (h *NewsExpiryHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsExpiryUseCase.Unarchive(r.Context(), uuid, version)
+	if err != nil {
+		h.expiryError(w, r, uuid, err)
+		return
+	}
+
+	h.writeNews(w, "News unarchived successfully", newsResponse)
+}
+
+func (h *NewsExpiryHandler) writeNews(w http.ResponseWriter, message string, newsResponse *response.NewsResponse) {
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+func (h *NewsExpiryHandler) expiryError(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	if errors.Is(err, common.ErrStaleVersion) {
+		staleNews(w, r, h.NewsUseCase, uuid, err)
+		return
+	}
+
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrExpiresAtNotInFuture) || errors.Is(err, usecase.ErrExpiresBeforePublish) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, entities.ErrInvalidTransition) {
+		code = http.StatusConflict
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		code = http.StatusNotFound
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
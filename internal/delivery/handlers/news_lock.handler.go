@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsLockHandler struct {
+	NewsLockUseCase usecase.NewsLockUseCase
+}
+
+func NewNewsLockHandler(newsLockUseCase usecase.NewsLockUseCase) *NewsLockHandler {
+	return &NewsLockHandler{NewsLockUseCase: newsLockUseCase}
+}
+
+// GetLock godoc
+// @Summary Get the edit lock of news
+// @Description Show who holds the edit lock and until when
+// @Tags News Locks
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.NewsLockResponse
+// @Failure 404 {object} response.ErrorResponse "News not found or not locked"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/lock [get]
+func (h *NewsLockHandler) GetLock(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	lock, err := h.NewsLockUseCase.GetLock(r.Context(), uuid)
+	if errors.Is(err, usecase.ErrNewsNotLocked) {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusNotFound, &errRes)
+		return
+	} else if err != nil {
+		lockError(w, err)
+		return
+	}
+
+	writeLock(w, "Data Found", lock)
+}
+
+// Lock godoc
+// @Summary Lock news for editing
+// @Description Check the news out to the X-Actor of the request for EDIT_LOCK_TTL. Locking again while holding the lock extends it. Editing news needs the lock: edits by anyone else are rejected with 423, and edits of news nobody locked with 428.
+// @Tags News Locks
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param X-Actor header string true "Who takes the lock"
+// @Success 200 {object} response.NewsLockResponse
+// @Failure 400 {object} response.ErrorResponse "X-Actor header missing"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/lock [post]
+func (h *NewsLockHandler) Lock(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	lock, err := h.NewsLockUseCase.Lock(r.Context(), uuid)
+	if err != nil {
+		lockError(w, err)
+		return
+	}
+
+	writeLock(w, "News locked successfully", lock)
+}
+
+// Heartbeat godoc
+// @Summary Extend an edit lock
+// @Description Extend the caller's lock by EDIT_LOCK_TTL from now. Fails once the lease has run out; lock again to take it back.
+// @Tags News Locks
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param X-Actor header string true "Holder of the lock"
+// @Success 200 {object} response.NewsLockResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Lock not held"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/lock [put]
+func (h *NewsLockHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	lock, err := h.NewsLockUseCase.Heartbeat(r.Context(), uuid)
+	if err != nil {
+		lockError(w, err)
+		return
+	}
+
+	writeLock(w, "Lock extended successfully", lock)
+}
+
+// Unlock godoc
+// @Summary Unlock news
+// @Description Check the news back in
+// @Tags News Locks
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param X-Actor header string true "Holder of the lock"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Not locked, or locked by someone else"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/lock [delete]
+func (h *NewsLockHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	if err := h.NewsLockUseCase.Unlock(r.Context(), uuid); err != nil {
+		lockError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "News unlocked successfully",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// BreakLock godoc
+// @Summary Break an edit lock
+// @Description Remove the lock whoever holds it, for instance after an editor left without unlocking. Needs X-Admin-Token. Recorded as a lock_break audit event.
+// @Tags News Locks
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.ErrorResponse "Not an admin"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not locked"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/lock/break [post]
+func (h *NewsLockHandler) BreakLock(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	if err := h.NewsLockUseCase.BreakLock(r.Context(), uuid); err != nil {
+		lockError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Lock broken successfully",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+func writeLock(w http.ResponseWriter, message string, lock *response.NewsLockResponse) {
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    lock,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// lockError maps someone else's lock to 423 and lock state conflicts to 409.
+func lockError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	if errors.Is(err, usecase.ErrNewsLocked) {
+		code = http.StatusLocked
+	} else if errors.Is(err, usecase.ErrLockNotHeld) || errors.Is(err, usecase.ErrNewsNotLocked) {
+		code = http.StatusConflict
+	} else if errors.Is(err, usecase.ErrAdminRequired) {
+		code = http.StatusForbidden
+	} else if errors.Is(err, usecase.ErrLockActorRequired) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		code = http.StatusNotFound
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsPendingEditHandler struct {
+	NewsPendingEditUseCase usecase.NewsPendingEditUseCase
+	NewsUseCase            usecase.NewsUseCase
+}
+
+func NewNewsPendingEditHandler(newsPendingEditUseCase usecase.NewsPendingEditUseCase, newsUseCase usecase.NewsUseCase) *NewsPendingEditHandler {
+	return &NewsPendingEditHandler{NewsPendingEditUseCase: newsPendingEditUseCase, NewsUseCase: newsUseCase}
+}
+
+// GetPendingEdit godoc
+// @Summary Preview the pending edit of news
+// @Description Get the working copy of published news as it will read once published
+// @Tags News Pending Edits
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.NewsPendingEditResponse
+// @Header 200 {string} ETag "Version of the working copy"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/pending [get]
+func (h *NewsPendingEditHandler) GetPendingEdit(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	edit, err := h.NewsPendingEditUseCase.GetPendingEdit(r.Context(), uuid)
+	if err != nil {
+		pendingEditError(w, err)
+		return
+	}
+
+	h.writePendingEdit(w, http.StatusOK, "Data Found", edit)
+}
+
+// StartPendingEdit godoc
+// @Summary Start a pending edit of published news
+// @Description Copy the live content of published news into a working copy. The live news is unchanged until the copy is published.
+// @Tags News Pending Edits
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the live news"
+// @Success 201 {object} response.NewsPendingEditResponse
+// @Header 201 {string} ETag "Version of the working copy"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not published or already has a pending edit"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/pending [post]
+func (h *NewsPendingEditHandler) StartPendingEdit(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	edit, err := h.NewsPendingEditUseCase.StartPendingEdit(r.Context(), uuid, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		staleNews(w, r, h.NewsUseCase, uuid, err)
+		return
+	} else if err != nil {
+		pendingEditError(w, err)
+		return
+	}
+
+	h.writePendingEdit(w, http.StatusCreated, "Pending edit started successfully", edit)
+}
+
+// UpdatePendingEdit godoc
+// @Summary Edit the pending copy of published news
+// @Description Change the working copy. Empty fields are left as they are; topics, when sent, replace the current ones.
+// @Tags News Pending Edits
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the working copy"
+// @Param edit body dtos.EditPendingNewsRequest true "Changes"
+// @Success 200 {object} response.NewsPendingEditResponse
+// @Header 200 {string} ETag "New version of the working copy"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current working copy is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/pending [put]
+func (h *NewsPendingEditHandler) UpdatePendingEdit(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var editDto dtos.EditPendingNewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&editDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	edit, err := h.NewsPendingEditUseCase.UpdatePendingEdit(r.Context(), uuid, version, editDto)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.stalePendingEdit(w, r, uuid, err)
+		return
+	} else if err != nil {
+		pendingEditError(w, err)
+		return
+	}
+
+	h.writePendingEdit(w, http.StatusOK, "Pending edit updated successfully", edit)
+}
+
+// DiscardPendingEdit godoc
+// @Summary Discard the pending edit of published news
+// @Description Throw the working copy away. The live news is unchanged.
+// @Tags News Pending Edits
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the working copy"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 412 {object} response.Response "Changed since it was read, the current working copy is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/pending [delete]
+func (h *NewsPendingEditHandler) DiscardPendingEdit(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.NewsPendingEditUseCase.DiscardPendingEdit(r.Context(), uuid, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.stalePendingEdit(w, r, uuid, err)
+		return
+	} else if err != nil {
+		pendingEditError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Pending edit discarded successfully",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// PublishPendingEdit godoc
+// @Summary Publish the pending edit of published news
+// @Description Replace the live content with the working copy in one transaction and remove the copy. Topics deleted since they were picked are skipped. Like publishing, it waits until every review comment is resolved.
+// @Tags News Pending Edits
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the working copy being published"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version of the live news"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not published or has unresolved review comments"
+// @Failure 412 {object} response.Response "Changed since it was read, the current working copy is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/pending/publish [post]
+func (h *NewsPendingEditHandler) PublishPendingEdit(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsPendingEditUseCase.PublishPendingEdit(r.Context(), uuid, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.stalePendingEdit(w, r, uuid, err)
+		return
+	} else if err != nil {
+		pendingEditError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Pending edit published successfully",
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+func (h *NewsPendingEditHandler) writePendingEdit(w http.ResponseWriter, code int, message string, edit *response.NewsPendingEditResponse) {
+	w.Header().Set("ETag", common.ETag(edit.Version))
+
+	webResponse := response.Response{
+		Code:    code,
+		Message: message,
+		Data:    edit,
+	}
+
+	response.NewResponseSuccess(w, code, webResponse)
+}
+
+// stalePendingEdit answers 412 with the current working copy, like
+// staleNews does for the live news.
+func (h *NewsPendingEditHandler) stalePendingEdit(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	webResponse := response.Response{
+		Code:    http.StatusPreconditionFailed,
+		Message: err.Error(),
+	}
+
+	if current, getErr := h.NewsPendingEditUseCase.GetPendingEdit(r.Context(), uuid); getErr == nil {
+		w.Header().Set("ETag", common.ETag(current.Version))
+		webResponse.Data = current
+	}
+
+	response.NewResponseSuccess(w, http.StatusPreconditionFailed, webResponse)
+}
+
+// pendingEditError maps a missing news item or working copy to 404,
+// publishing conflicts to 409 and edit lock problems to 423 or 428.
+func pendingEditError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "pending edit not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) || err.Error() == "topic not found" {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrNewsNotPublished) || errors.Is(err, usecase.ErrPendingEditExists) || errors.Is(err, usecase.ErrPendingEditUnresolved) {
+		code = http.StatusConflict
+	} else if errors.Is(err, usecase.ErrNewsLocked) {
+		code = http.StatusLocked
+	} else if errors.Is(err, usecase.ErrEditLockRequired) {
+		code = http.StatusPreconditionRequired
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsReviewCommentHandler struct {
+	NewsReviewCommentUseCase usecase.NewsReviewCommentUseCase
+}
+
+func NewNewsReviewCommentHandler(newsReviewCommentUseCase usecase.NewsReviewCommentUseCase) *NewsReviewCommentHandler {
+	return &NewsReviewCommentHandler{NewsReviewCommentUseCase: newsReviewCommentUseCase}
+}
+
+// GetComments godoc
+// @Summary Get news review comments
+// @Description Get the review comment threads of a news item, oldest first, with replies nested under the comment they answer
+// @Tags News Review Comments
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Success 200 {object} response.NewsReviewCommentsResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/comments [get]
+func (h *NewsReviewCommentHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	comments, err := h.NewsReviewCommentUseCase.GetComments(r.Context(), uuid)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    comments,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// CreateComment godoc
+// @Summary Comment on news
+// @Description Start a review thread, optionally anchored to a character range of the content, or reply to a comment with parent_uuid. The author is taken from X-Actor.
+// @Tags News Review Comments
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param comment body dtos.CreateNewsReviewCommentRequest true "Comment"
+// @Success 201 {object} response.NewsReviewCommentResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "New thread on scheduled news"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/comments [post]
+func (h *NewsReviewCommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	var commentDto dtos.CreateNewsReviewCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&commentDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	comment, err := h.NewsReviewCommentUseCase.CreateComment(r.Context(), uuid, commentDto)
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusCreated,
+		Message: "Comment created successfully",
+		Data:    comment,
+	}
+
+	response.NewResponseSuccess(w, http.StatusCreated, webResponse)
+}
+
+// ResolveComment godoc
+// @Summary Resolve a review thread
+// @Description Resolve the thread started by the comment. Publishing is blocked while threads are unresolved.
+// @Tags News Review Comments
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param comment path string true "UUID of the first comment of the thread"
+// @Success 200 {object} response.NewsReviewCommentResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Already resolved, or not the first comment of a thread"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/comments/{comment}/resolve [post]
+func (h *NewsReviewCommentHandler) ResolveComment(w http.ResponseWriter, r *http.Request) {
+	h.setResolved(w, r, "Comment resolved successfully", h.NewsReviewCommentUseCase.ResolveComment)
+}
+
+// ReopenComment godoc
+// @Summary Reopen a review thread
+// @Description Reopen a resolved thread
+// @Tags News Review Comments
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param comment path string true "UUID of the first comment of the thread"
+// @Success 200 {object} response.NewsReviewCommentResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Not resolved, not the first comment of a thread, or the news is scheduled"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/comments/{comment}/reopen [post]
+func (h *NewsReviewCommentHandler) ReopenComment(w http.ResponseWriter, r *http.Request) {
+	h.setResolved(w, r, "Comment reopened successfully", h.NewsReviewCommentUseCase.ReopenComment)
+}
+
+func (h *NewsReviewCommentHandler) setResolved(w http.ResponseWriter, r *http.Request, message string, set func(ctx context.Context, newsUuid string, commentUuid string) (*response.NewsReviewCommentResponse, error)) {
+	comment, err := set(r.Context(), chi.URLParam(r, "uuid"), chi.URLParam(r, "comment"))
+	if err != nil {
+		commentError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    comment,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// commentError maps a missing news item or comment to 404, invalid input to
+// 400 and a thread in the wrong state to 409.
+func commentError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "comment not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrCommentAnchorOutOfRange) || errors.Is(err, usecase.ErrReplyAnchored) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrCommentNotThread) || errors.Is(err, usecase.ErrThreadResolved) || errors.Is(err, usecase.ErrThreadNotResolved) || errors.Is(err, usecase.ErrNewsScheduledThread) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsRevisionHandler struct {
+	NewsRevisionUseCase usecase.NewsRevisionUseCase
+	NewsUseCase         usecase.NewsUseCase
+}
+
+func NewNewsRevisionHandler(newsRevisionUseCase usecase.NewsRevisionUseCase, newsUseCase usecase.NewsUseCase) *NewsRevisionHandler {
+	return &NewsRevisionHandler{NewsRevisionUseCase: newsRevisionUseCase, NewsUseCase: newsUseCase}
+}
+
+// GetRevisions godoc
+// @Summary Get news revisions
+// @Description Get the revisions of a news item, newest first
+// @Tags News Revisions
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Param per_page query int false "Number of revisions per page" default(5)
+// @Param page query int false "Current page number" default(1)
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/revisions [get]
+func (h *NewsRevisionHandler) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	per_page := 5
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	revisions, totalItems, err := h.NewsRevisionUseCase.GetRevisions(r.Context(), uuid, pagination)
+	if err != nil {
+		revisionError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    revisions,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(revisions)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetRevision godoc
+// @Summary Get a news revision
+// @Description Get one revision of a news item by its number
+// @Tags News Revisions
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} response.NewsRevisionResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/revisions/{revision} [get]
+func (h *NewsRevisionHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid revision",
+		}
+
+		response.NewResponseError(w, http.StatusBadRequest, &errRes)
+		return
+	}
+
+	revisionResponse, err := h.NewsRevisionUseCase.GetRevision(r.Context(), uuid, revision)
+	if err != nil {
+		revisionError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    revisionResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// DiffRevisions godoc
+// @Summary Diff two news revisions
+// @Description Compare two revisions of a news item. Title and content are diffed line by line or word by word; status, language and topic changes are listed separately. Texts of more than 20000 lines or words and spaces together are refused with 422.
+// @Tags News Revisions
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Param from query int true "Older revision number"
+// @Param to query int true "Newer revision number"
+// @Param mode query string false "Diff granularity" Enums(line, word) default(line)
+// @Success 200 {object} response.NewsRevisionDiffResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 422 {object} response.ErrorResponse "Texts too long to diff"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/revisions/diff [get]
+func (h *NewsRevisionHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	// unparsable numbers stay 0 and are rejected by validation
+	from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+	to, _ := strconv.Atoi(r.URL.Query().Get("to"))
+
+	diffDto := dtos.DiffNewsRevisionsRequest{
+		From: from,
+		To:   to,
+		Mode: r.URL.Query().Get("mode"),
+	}
+
+	diff, err := h.NewsRevisionUseCase.DiffRevisions(r.Context(), uuid, diffDto)
+	if err != nil {
+		revisionError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    diff,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// RestoreRevision godoc
+// @Summary Restore a news revision
+// @Description Copy a past revision back onto the news item as a draft. Topics deleted since then are skipped.
+// @Tags News Revisions
+// @Produce  json
+// @Param uuid path string true "News UUID"
+// @Param revision path int true "Revision number"
+// @Param If-Match header string true "ETag of the current news version"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Status change not allowed"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 423 {object} response.ErrorResponse "Locked by someone else"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing, or the news is not locked by you"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/revisions/{revision}/restore [post]
+func (h *NewsRevisionHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid revision",
+		}
+
+		response.NewResponseError(w, http.StatusBadRequest, &errRes)
+		return
+	}
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsRevisionUseCase.RestoreRevision(r.Context(), uuid, revision, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		staleNews(w, r, h.NewsUseCase, uuid, err)
+		return
+	} else if err != nil {
+		revisionError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Revision restored successfully",
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// revisionError maps a missing news item or revision to 404, invalid input
+// to 400, someone else's edit lock to 423 and a missing one to 428.
+func revisionError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "revision not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, common.ErrDiffTooLarge) {
+		code = http.StatusUnprocessableEntity
+	} else if errors.Is(err, entities.ErrInvalidTransition) {
+		code = http.StatusConflict
+	} else if errors.Is(err, usecase.ErrNewsLocked) {
+		code = http.StatusLocked
+	} else if errors.Is(err, usecase.ErrEditLockRequired) {
+		code = http.StatusPreconditionRequired
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-topic-api/common"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/usecase"
+)
+
+type NewsScheduleHandler struct {
+	NewsScheduleUseCase usecase.NewsScheduleUseCase
+	NewsUseCase         usecase.NewsUseCase
+}
+
+func NewNewsScheduleHandler(newsScheduleUseCase usecase.NewsScheduleUseCase, newsUseCase usecase.NewsUseCase) *NewsScheduleHandler {
+	return &NewsScheduleHandler{NewsScheduleUseCase: newsScheduleUseCase, NewsUseCase: newsUseCase}
+}
+
+// ScheduleNews godoc
+// @Summary Schedule news
+// @Description Schedule approved news to be published at publish_at
+// @Tags News Schedule
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Param schedule body dtos.ScheduleNewsRequest true "Publish time"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not approved"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/schedule [post]
+func (h *NewsScheduleHandler) ScheduleNews(w http.ResponseWriter, r *http.Request) {
+	h.writeSchedule(w, r, "News scheduled successfully", h.NewsScheduleUseCase.ScheduleNews)
+}
+
+// RescheduleNews godoc
+// @Summary Reschedule news
+// @Description Move the publish time of scheduled news
+// @Tags News Schedule
+// @Accept json
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Param schedule body dtos.ScheduleNewsRequest true "New publish time"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not scheduled"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/schedule [put]
+func (h *NewsScheduleHandler) RescheduleNews(w http.ResponseWriter, r *http.Request) {
+	h.writeSchedule(w, r, "News rescheduled successfully", h.NewsScheduleUseCase.RescheduleNews)
+}
+
+// CancelSchedule godoc
+// @Summary Cancel scheduled publishing
+// @Description Take news off the schedule, it goes back to approved
+// @Tags News Schedule
+// @Produce json
+// @Param uuid path string true "News UUID"
+// @Param If-Match header string true "ETag of the version being changed"
+// @Success 200 {object} response.NewsResponse
+// @Header 200 {string} ETag "New version"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News is not scheduled"
+// @Failure 412 {object} response.Response "Changed since it was read, the current news is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /news/{uuid}/schedule [delete]
+func (h *NewsScheduleHandler) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	newsResponse, err := h.NewsScheduleUseCase.CancelSchedule(r.Context(), uuid, version)
+	if err != nil {
+		h.scheduleError(w, r, uuid, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Schedule cancelled successfully",
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// writeSchedule decodes the publish time and applies it with write.
+func (h *NewsScheduleHandler) writeSchedule(w http.ResponseWriter, r *http.Request, message string, write func(ctx context.Context, uuid string, version int, dto dtos.ScheduleNewsRequest) (*response.NewsResponse, error)) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var scheduleDto dtos.ScheduleNewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&scheduleDto); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	newsResponse, err := write(r.Context(), uuid, version, scheduleDto)
+	if err != nil {
+		h.scheduleError(w, r, uuid, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(newsResponse.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: message,
+		Data:    newsResponse,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+func (h *NewsScheduleHandler) scheduleError(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	if errors.Is(err, common.ErrStaleVersion) {
+		staleNews(w, r, h.NewsUseCase, uuid, err)
+		return
+	}
+
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrPublishAtNotInFuture) || errors.Is(err, usecase.ErrExpiresBeforePublish) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, entities.ErrInvalidTransition) || errors.Is(err, usecase.ErrNewsNotScheduled) {
+		code = http.StatusConflict
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		code = http.StatusNotFound
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
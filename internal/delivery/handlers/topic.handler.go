@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"news-topic-api/common"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 
 	"news-topic-api/internal/delivery/data/dtos"
 	response "news-topic-api/internal/delivery/data/responses"
@@ -22,11 +25,12 @@ func NewTopicHandler(topicUseCase usecase.TopicUseCase) *TopicHandler {
 
 // GetAllTopics godoc
 // @Summary Get all topics
-// @Description Get all topics with pagination
+// @Description Get all topics with offset pagination, or with cursor pagination when a cursor parameter is sent (empty for the first page)
 // @Tags Topics
 // @Produce  json
 // @Param per_page query int false "Number of topics per page" default(5)
 // @Param page query int false "Current page number" default(1)
+// @Param cursor query string false "Opaque cursor from meta.cursor.next_cursor; send it empty to start cursor pagination"
 // @Success 200 {array} response.Response
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
@@ -38,19 +42,46 @@ func (h *TopicHandler) GetTopics(w http.ResponseWriter, r *http.Request) {
 	pp, p := common.ExtractPaginationParams(r, per_page, page)
 	offset := (p - 1) * pp
 
+	keyset, cursor, err := common.ExtractCursorParam(r)
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusBadRequest, &errRes)
+		return
+	}
+
 	pagination := &common.Pagination{
 		Limit:  pp,
 		Offset: offset,
 		Page:   p,
+		Keyset: keyset,
+		Cursor: cursor,
 	}
 
-	topics, totalItems, err := h.TopicUseCase.GetAllTopics(pagination)
+	topics, totalItems, err := h.TopicUseCase.GetAllTopics(r.Context(), pagination)
 	if err != nil {
+		if errors.Is(err, common.ErrInvalidPagination) {
+			errRes := response.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}
+
+			response.NewResponseError(w, http.StatusBadRequest, &errRes)
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	meta := common.NewMeta(totalItems, pp, page, offset, len(topics))
+	meta := common.NewMeta(totalItems, pp, p, offset, len(topics))
+	if keyset {
+		meta = common.NewCursorMeta(pp, cursor, pagination.NextCursor)
+	}
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "Data Found",
@@ -68,6 +99,7 @@ func (h *TopicHandler) GetTopics(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param uuid path string true "Topic UUID"
 // @Success 200 {object} response.TopicResponse
+// @Header 200 {string} ETag "Current version, send it back as If-Match when writing"
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
@@ -75,7 +107,7 @@ func (h *TopicHandler) GetTopics(w http.ResponseWriter, r *http.Request) {
 func (h *TopicHandler) GetTopic(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
-	topic, err := h.TopicUseCase.GetByUuid(uuid)
+	topic, err := h.TopicUseCase.GetByUuid(r.Context(), uuid)
 	if err != nil {
 		errRes := response.ErrorResponse{
 			Code:    http.StatusForbidden,
@@ -86,6 +118,83 @@ func (h *TopicHandler) GetTopic(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(topic.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    topic,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetTopicTree godoc
+// @Summary Get the topic tree
+// @Description Get every topic nested under its parent, each level ordered by title
+// @Tags Topics
+// @Produce  json
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/tree [get]
+func (h *TopicHandler) GetTopicTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.TopicUseCase.GetTree(r.Context())
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusInternalServerError, &errRes)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    tree,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// LookupTopic godoc
+// @Summary Look up a topic by value
+// @Description Find the topic a value names, either as its own value or as one of its aliases
+// @Tags Topics
+// @Produce  json
+// @Param value query string true "Topic value or alias"
+// @Success 200 {object} response.TopicResponse
+// @Header 200 {string} ETag "Current version, send it back as If-Match when writing"
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/lookup [get]
+func (h *TopicHandler) LookupTopic(w http.ResponseWriter, r *http.Request) {
+	value := r.URL.Query().Get("value")
+	if value == "" {
+		badRequest(w, errors.New("value is required"))
+		return
+	}
+
+	topic, err := h.TopicUseCase.LookupByValue(r.Context(), value)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if err.Error() == "topic not found" {
+			code = http.StatusNotFound
+		}
+
+		errRes := response.ErrorResponse{
+			Code:    code,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, code, &errRes)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(topic.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "Data Found",
@@ -104,6 +213,7 @@ func (h *TopicHandler) GetTopic(w http.ResponseWriter, r *http.Request) {
 // @Param topic body dtos.CreateTopicRequest  true  "Create Topic Request"
 // @Success 201 {object} response.TopicResponse
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 409 {object} response.ErrorResponse "Value is an alias of a topic"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /topic [post]
 func (h *TopicHandler) CreateTopic(w http.ResponseWriter, r *http.Request) {
@@ -113,17 +223,26 @@ func (h *TopicHandler) CreateTopic(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	topic, err := h.TopicUseCase.CreateTopic(req)
+	topic, err := h.TopicUseCase.CreateTopic(r.Context(), req)
 	if err != nil {
+		code := http.StatusForbidden
+		if errors.Is(err, usecase.ErrParentTopicNotFound) {
+			code = http.StatusBadRequest
+		} else if errors.Is(err, usecase.ErrTopicValueIsAlias) {
+			code = http.StatusConflict
+		}
+
 		errRes := response.ErrorResponse{
-			Code:    http.StatusForbidden,
+			Code:    code,
 			Message: err.Error(),
 		}
 
-		response.NewResponseError(w, http.StatusForbidden, &errRes)
+		response.NewResponseError(w, code, &errRes)
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(topic.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusCreated,
 		Message: "Topic created successfully",
@@ -140,32 +259,54 @@ func (h *TopicHandler) CreateTopic(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param uuid path string true "Topic UUID"
+// @Param If-Match header string true "ETag of the version being changed"
 // @Param topic body dtos.UpdateTopicRequest  true  "Update Topic Request"
 // @Success 200 {object} response.TopicResponse
+// @Header 200 {string} ETag "New version"
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Parent is the topic itself or one of its descendants"
+// @Failure 412 {object} response.Response "Changed since it was read, the current topic is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /topic/{uuid} [put]
 func (h *TopicHandler) UpdateTopic(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	var req dtos.UpdateTopicRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	topic, err := h.TopicUseCase.UpdateByUuid(uuid, req)
+	topic, err := h.TopicUseCase.UpdateByUuid(r.Context(), uuid, version, req)
 	if err != nil {
+		if errors.Is(err, common.ErrStaleVersion) {
+			h.staleTopic(w, r, uuid, err)
+			return
+		}
+
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrTopicCycle) {
+			code = http.StatusConflict
+		}
+
 		errRes := response.ErrorResponse{
-			Code:    http.StatusBadRequest,
+			Code:    code,
 			Message: err.Error(),
 		}
 
-		response.NewResponseError(w, http.StatusBadRequest, &errRes)
+		response.NewResponseError(w, code, &errRes)
 		return
 	}
 
+	w.Header().Set("ETag", common.ETag(topic.Version))
+
 	webResponse := response.Response{
 		Code:    http.StatusOK,
 		Message: "Topic updated successfully",
@@ -182,22 +323,39 @@ func (h *TopicHandler) UpdateTopic(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param uuid path string true "Topic UUID"
+// @Param If-Match header string true "ETag of the version being deleted"
 // @Success 200 {object} response.ErrorResponse "OK"
 // @Failure 400 {object} response.ErrorResponse "Bad Request"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Topic still has child topics"
+// @Failure 412 {object} response.Response "Changed since it was read, the current topic is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} response.ErrorResponse "Internal Server Error"
 // @Router /topic/{uuid} [delete]
 func (h *TopicHandler) DeleteTopic(w http.ResponseWriter, r *http.Request) {
 	uuid := chi.URLParam(r, "uuid")
 
-	err := h.TopicUseCase.DeleteByUuid(uuid)
-	if err != nil {
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.TopicUseCase.DeleteByUuid(r.Context(), uuid, version)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.staleTopic(w, r, uuid, err)
+		return
+	} else if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrTopicHasChildren) {
+			code = http.StatusConflict
+		}
+
 		response := response.Response{
-			Code:    http.StatusBadRequest,
+			Code:    code,
 			Message: err.Error(),
 		}
 
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(code)
 		json.NewEncoder(w).Encode(response)
 		return
 	}
@@ -209,3 +367,189 @@ func (h *TopicHandler) DeleteTopic(w http.ResponseWriter, r *http.Request) {
 
 	response.NewResponseSuccess(w, http.StatusOK, webResponse)
 }
+
+// MergeTopic godoc
+// @Summary Merge a topic into another
+// @Description Move the news, pins and child topics of the topic to the target topic in one transaction, then delete the topic for good. Its value stays behind as an alias of the target, so news filters on it keep working.
+// @Tags Topics
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "UUID of the topic merged away"
+// @Param If-Match header string true "ETag of the version being merged"
+// @Param merge body dtos.MergeTopicRequest true "Target topic"
+// @Success 200 {object} response.TopicMergeResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Target is below the topic"
+// @Failure 412 {object} response.Response "Changed since it was read, the current topic is in data"
+// @Failure 428 {object} response.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/merge [post]
+func (h *TopicHandler) MergeTopic(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	version, ok := versionFromIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var req dtos.MergeTopicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	merge, err := h.TopicUseCase.MergeTopic(r.Context(), uuid, version, req)
+	if errors.Is(err, common.ErrStaleVersion) {
+		h.staleTopic(w, r, uuid, err)
+		return
+	} else if err != nil {
+		mergeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", common.ETag(merge.Topic.Version))
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Topic merged successfully",
+		Data:    merge,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// GetTrashedTopics godoc
+// @Summary Get deleted topics
+// @Description Get soft-deleted topics, most recently deleted first
+// @Tags Topics Trash
+// @Produce  json
+// @Param per_page query int false "Number of topics per page" default(5)
+// @Param page query int false "Current page number" default(1)
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/trash [get]
+func (h *TopicHandler) GetTrashedTopics(w http.ResponseWriter, r *http.Request) {
+	per_page := 5
+	page := 1
+
+	pp, p := common.ExtractPaginationParams(r, per_page, page)
+	offset := (p - 1) * pp
+
+	pagination := &common.Pagination{
+		Limit:  pp,
+		Offset: offset,
+		Page:   p,
+	}
+
+	topics, totalItems, err := h.TopicUseCase.GetTrashedTopics(r.Context(), pagination)
+	if err != nil {
+		errRes := response.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+
+		response.NewResponseError(w, http.StatusInternalServerError, &errRes)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    topics,
+		Meta:    common.NewMeta(totalItems, pp, p, offset, len(topics)),
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// RestoreTopic godoc
+// @Summary Restore deleted topic
+// @Description Take a topic out of the trash and attach it again to the news it was linked to
+// @Tags Topics Trash
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Success 200 {object} response.TopicResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/trash/{uuid}/restore [post]
+func (h *TopicHandler) RestoreTopic(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	restoredTopic, err := h.TopicUseCase.RestoreByUuid(r.Context(), uuid)
+	if err != nil {
+		trashError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Topic restored successfully",
+		Data:    restoredTopic,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// PurgeTopic godoc
+// @Summary Permanently delete topic
+// @Description Permanently delete a topic from the trash
+// @Tags Topics Trash
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/trash/{uuid} [delete]
+func (h *TopicHandler) PurgeTopic(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	if err := h.TopicUseCase.PurgeByUuid(r.Context(), uuid); err != nil {
+		trashError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Topic permanently deleted",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// staleTopic answers 412 with the current topic, like staleNews.
+// mergeError maps a missing topic to 404, an invalid target to 400 and a
+// target below the merged topic to 409.
+func mergeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "topic not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrMergeTargetNotFound) || errors.Is(err, usecase.ErrMergeIntoItself) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrMergeIntoDescendant) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
+
+func (h *TopicHandler) staleTopic(w http.ResponseWriter, r *http.Request, uuid string, err error) {
+	webResponse := response.Response{
+		Code:    http.StatusPreconditionFailed,
+		Message: err.Error(),
+	}
+
+	if current, getErr := h.TopicUseCase.GetByUuid(r.Context(), uuid); getErr == nil {
+		w.Header().Set("ETag", common.ETag(current.Version))
+		webResponse.Data = current
+	}
+
+	response.NewResponseSuccess(w, http.StatusPreconditionFailed, webResponse)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type TopicAliasHandler struct {
+	TopicAliasUseCase usecase.TopicAliasUseCase
+}
+
+func NewTopicAliasHandler(topicAliasUseCase usecase.TopicAliasUseCase) *TopicAliasHandler {
+	return &TopicAliasHandler{TopicAliasUseCase: topicAliasUseCase}
+}
+
+// GetAliases godoc
+// @Summary Get topic aliases
+// @Description Get the other values a topic answers to in GET /news?topic=..., by value
+// @Tags Topic Aliases
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Success 200 {array} response.TopicAliasResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/aliases [get]
+func (h *TopicAliasHandler) GetAliases(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	aliases, err := h.TopicAliasUseCase.GetAliases(r.Context(), uuid)
+	if err != nil {
+		aliasError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    aliases,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// CreateAlias godoc
+// @Summary Add a topic alias
+// @Description Add another value the topic answers to. It cannot be the value of any topic or another alias.
+// @Tags Topic Aliases
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param alias body dtos.TopicAliasRequest true "Alias"
+// @Success 201 {object} response.TopicAliasResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Value already taken"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/aliases [post]
+func (h *TopicAliasHandler) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	var req dtos.TopicAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	alias, err := h.TopicAliasUseCase.CreateAlias(r.Context(), uuid, req)
+	if err != nil {
+		aliasError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusCreated,
+		Message: "Topic alias created successfully",
+		Data:    alias,
+	}
+
+	response.NewResponseSuccess(w, http.StatusCreated, webResponse)
+}
+
+// UpdateAlias godoc
+// @Summary Rename a topic alias
+// @Description Change the value of a topic alias
+// @Tags Topic Aliases
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param alias_uuid path string true "Alias UUID"
+// @Param alias body dtos.TopicAliasRequest true "Alias"
+// @Success 200 {object} response.TopicAliasResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Value already taken"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/aliases/{alias_uuid} [put]
+func (h *TopicAliasHandler) UpdateAlias(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	aliasUuid := chi.URLParam(r, "alias_uuid")
+
+	var req dtos.TopicAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	alias, err := h.TopicAliasUseCase.UpdateAlias(r.Context(), uuid, aliasUuid, req)
+	if err != nil {
+		aliasError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Topic alias updated successfully",
+		Data:    alias,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// DeleteAlias godoc
+// @Summary Remove a topic alias
+// @Description Remove a topic alias; news filters on its value stop matching the topic
+// @Tags Topic Aliases
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param alias_uuid path string true "Alias UUID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/aliases/{alias_uuid} [delete]
+func (h *TopicAliasHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	aliasUuid := chi.URLParam(r, "alias_uuid")
+
+	if err := h.TopicAliasUseCase.DeleteAlias(r.Context(), uuid, aliasUuid); err != nil {
+		aliasError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Topic alias deleted successfully",
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// aliasError maps a missing topic or alias to 404, invalid input to 400 and
+// a value already in use to 409.
+func aliasError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if err.Error() == "topic not found" || err.Error() == "topic alias not found" {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrAliasTaken) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
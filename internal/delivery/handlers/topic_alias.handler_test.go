@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// list returns the UUIDs a list endpoint answers with.
+func (s *testServer) list(path string) []string {
+	s.t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1"+path, nil))
+	if rec.Code != http.StatusOK {
+		s.t.Fatalf("GET %s: got %d %s", path, rec.Code, rec.Body.String())
+	}
+
+	var res struct {
+		Data []struct {
+			UUID string `json:"uuid"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		s.t.Fatal(err)
+	}
+
+	uuids := []string{}
+	for _, item := range res.Data {
+		uuids = append(uuids, item.UUID)
+	}
+	return uuids
+}
+
+func TestTopicAliases(t *testing.T) {
+	eachTestServer(t, func(t *testing.T, s *testServer) {
+		football, _ := s.created(http.MethodPost, "/topics", `{"title":"Football","value":"football"}`)
+		politics, _ := s.created(http.MethodPost, "/topics", `{"title":"Politics","value":"politics"}`)
+		s.created(http.MethodPost, "/topics/"+football+"/aliases", `{"value":"soccer"}`)
+
+		for _, c := range []struct {
+			name, path, body string
+		}{
+			{"alias taking a topic value", "/topics/" + football + "/aliases", `{"value":"politics"}`},
+			{"alias taking its own topic value", "/topics/" + football + "/aliases", `{"value":"football"}`},
+			{"alias taking another alias", "/topics/" + politics + "/aliases", `{"value":"soccer"}`},
+			{"topic taking an alias", "/topics", `{"title":"Soccer","value":"soccer"}`},
+		} {
+			if rec, _ := s.do(http.MethodPost, c.path, c.body, ""); rec.Code != http.StatusConflict {
+				t.Errorf("%s: got %d %s, want 409", c.name, rec.Code, rec.Body.String())
+			}
+		}
+
+		news, _ := s.created(http.MethodPost, "/news", `{"title":"Final tonight","content":"c","status":"draft","topics":[{"uuid":"`+football+`"}]}`)
+		s.created(http.MethodPost, "/news", `{"title":"Elections","content":"c","status":"draft","topics":[{"uuid":"`+politics+`"}]}`)
+
+		if listed := s.list("/news?topic=soccer"); len(listed) != 1 || listed[0] != news {
+			t.Errorf("news filtered by the alias: got %v, want only %s", listed, news)
+		}
+
+		for _, value := range []string{"soccer", "football"} {
+			rec, res := s.do(http.MethodGet, "/topics/lookup?value="+value, "", "")
+			if rec.Code != http.StatusOK || res.Data.UUID != football {
+				t.Errorf("looking up %s: got %d with %s, want football %s", value, rec.Code, res.Data.UUID, football)
+			}
+		}
+		if rec, _ := s.do(http.MethodGet, "/topics/lookup?value=cricket", "", ""); rec.Code != http.StatusNotFound {
+			t.Errorf("looking up an unknown value: got %d, want 404", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/usecase"
+)
+
+type TopicPinHandler struct {
+	TopicPinUseCase usecase.TopicPinUseCase
+}
+
+func NewTopicPinHandler(topicPinUseCase usecase.TopicPinUseCase) *TopicPinHandler {
+	return &TopicPinHandler{TopicPinUseCase: topicPinUseCase}
+}
+
+// GetPins godoc
+// @Summary Get topic pins
+// @Description Get the news pinned to a topic, in the order they are listed first by GET /news?topic=...
+// @Tags Topic Pins
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Success 200 {array} response.TopicPinResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/pins [get]
+func (h *TopicPinHandler) GetPins(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	pins, err := h.TopicPinUseCase.GetPins(r.Context(), uuid)
+	if err != nil {
+		pinError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Data Found",
+		Data:    pins,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// PinNews godoc
+// @Summary Pin news to a topic
+// @Description Pin a news item of the topic at a position, optionally until expires_at. Pinning news that is already pinned moves it and replaces its expiry.
+// @Tags Topic Pins
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param pin body dtos.PinNewsRequest true "Pin"
+// @Success 200 {array} response.TopicPinResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "News not in the topic"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/pins [post]
+func (h *TopicPinHandler) PinNews(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	var req dtos.PinNewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	pins, err := h.TopicPinUseCase.PinNews(r.Context(), uuid, req)
+	if err != nil {
+		pinError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "News pinned successfully",
+		Data:    pins,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// ReorderPins godoc
+// @Summary Reorder topic pins
+// @Description Put the pinned news of a topic in a new order. Every pinned news item must be listed exactly once.
+// @Tags Topic Pins
+// @Accept  json
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param pins body dtos.ReorderTopicPinsRequest true "News UUIDs in their new order"
+// @Success 200 {array} response.TopicPinResponse
+// @Failure 400 {object} response.ErrorResponse "Bad Request"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 409 {object} response.ErrorResponse "Pins changed since they were read"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/pins [put]
+func (h *TopicPinHandler) ReorderPins(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+
+	var req dtos.ReorderTopicPinsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	pins, err := h.TopicPinUseCase.ReorderPins(r.Context(), uuid, req)
+	if err != nil {
+		pinError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "Pins reordered successfully",
+		Data:    pins,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// UnpinNews godoc
+// @Summary Unpin news from a topic
+// @Description Unpin a news item; the pins after it move up
+// @Tags Topic Pins
+// @Produce  json
+// @Param uuid path string true "Topic UUID"
+// @Param news_uuid path string true "News UUID"
+// @Success 200 {array} response.TopicPinResponse
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse "Internal Server Error"
+// @Router /topics/{uuid}/pins/{news_uuid} [delete]
+func (h *TopicPinHandler) UnpinNews(w http.ResponseWriter, r *http.Request) {
+	uuid := chi.URLParam(r, "uuid")
+	newsUuid := chi.URLParam(r, "news_uuid")
+
+	pins, err := h.TopicPinUseCase.UnpinNews(r.Context(), uuid, newsUuid)
+	if err != nil {
+		pinError(w, err)
+		return
+	}
+
+	webResponse := response.Response{
+		Code:    http.StatusOK,
+		Message: "News unpinned successfully",
+		Data:    pins,
+	}
+
+	response.NewResponseSuccess(w, http.StatusOK, webResponse)
+}
+
+// pinError maps a missing topic, news item or pin to 404, invalid input to
+// 400, and news outside the topic or a stale reorder to 409.
+func pinError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	var validationErrs validator.ValidationErrors
+	if errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "topic not found" || errors.Is(err, usecase.ErrNewsNotPinned) {
+		code = http.StatusNotFound
+	} else if errors.As(err, &validationErrs) || errors.Is(err, usecase.ErrPinExpiryNotInFuture) {
+		code = http.StatusBadRequest
+	} else if errors.Is(err, usecase.ErrNewsNotInTopic) || errors.Is(err, usecase.ErrPinsMismatch) {
+		code = http.StatusConflict
+	}
+
+	errRes := response.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	response.NewResponseError(w, code, &errRes)
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"news-topic-api/common"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate       AuditAction = "create"
+	AuditActionUpdate       AuditAction = "update"
+	AuditActionStatusChange AuditAction = "status_change"
+	AuditActionDelete       AuditAction = "delete"
+	AuditActionRestore      AuditAction = "restore"
+	AuditActionPurge        AuditAction = "purge"
+	// AuditActionEmbargoLift is recorded when news leaves its embargo, by
+	// hand or once embargo_until has passed.
+	AuditActionEmbargoLift AuditAction = "embargo_lift"
+	// AuditActionLockBreak is recorded when an admin breaks an edit lock
+	// held by someone else.
+	AuditActionLockBreak AuditAction = "lock_break"
+	// AuditActionPinsChange is recorded on a topic when news is pinned,
+	// unpinned or its pins are reordered.
+	AuditActionPinsChange AuditAction = "pins_change"
+	// AuditActionMerge is recorded on a topic merged into another one and
+	// removed.
+	AuditActionMerge AuditAction = "merge"
+	// AuditActionAliasesChange is recorded on a topic when one of its
+	// aliases is added, renamed or removed.
+	AuditActionAliasesChange AuditAction = "aliases_change"
+)
+
+const (
+	AuditEntityNews   = "news"
+	AuditEntityTopic  = "topic"
+	AuditEntityAuthor = "author"
+)
+
+// AuditEvent records who changed a news item, topic or author and how. Events are
+// append-only and outlive the entity they describe, so there is no foreign
+// key to it.
+type AuditEvent struct {
+	common.Base
+	Actor      string      `gorm:"type:varchar(255)" json:"actor"`
+	Action     AuditAction `gorm:"type:varchar(50)" json:"action"`
+	EntityType string      `gorm:"type:varchar(50)" json:"entity_type"`
+	EntityUuid string      `json:"entity_uuid"`
+	RequestId  string      `gorm:"type:varchar(255)" json:"request_id"`
+	// Before and After are the entity as the API returned it, null when it
+	// did not exist before or after the change.
+	Before    json.RawMessage `gorm:"serializer:json" json:"before"`
+	After     json.RawMessage `gorm:"serializer:json" json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+)
+
+// Author is a person credited in the byline of news.
+type Author struct {
+	common.Base
+	Name string `gorm:"type:varchar(255)" json:"name"`
+	// Slug names the author in URLs and in the author filter of the news
+	// listing. It is unique among authors that are not deleted.
+	Slug      string `gorm:"type:varchar(255)" json:"slug"`
+	Bio       string `gorm:"type:text" json:"bio"`
+	AvatarURL string `gorm:"type:varchar(2048)" json:"avatar_url"`
+	// Version is bumped on every write and served as the ETag.
+	Version int `gorm:"not null;default:1" json:"version"`
+	gorm.Model
+}
+
+// NewsAuthor places an author in the byline of a news item. Bylines are
+// ordered by Position, starting at 0.
+type NewsAuthor struct {
+	NewsId   uint `gorm:"primaryKey;autoIncrement:false" json:"news_id"`
+	AuthorId uint `gorm:"primaryKey;autoIncrement:false" json:"author_id"`
+	Position int  `gorm:"not null" json:"position"`
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"time"
+
 	"news-topic-api/common"
 
 	"gorm.io/gorm"
@@ -8,17 +10,73 @@ import (
 
 type StatusType string
 
+// The editorial statuses of a news item. The moves allowed between them are
+// listed in news_transition.entity.go.
 const (
+	NewsStatusDraft    StatusType = "draft"
+	NewsStatusInReview StatusType = "in_review"
+	NewsStatusApproved StatusType = "approved"
+	// NewsStatusScheduled news is published by the publisher worker once
+	// its PublishAt has passed.
+	NewsStatusScheduled StatusType = "scheduled"
 	NewsStatusPublished StatusType = "published"
-	NewsStatusDraft     StatusType = "draft"
-	NewsStatusDeleted   StatusType = "deleted"
+	// NewsStatusArchived news is kept but left out of listings unless asked
+	// for. The expirer worker archives published news once its ExpiresAt
+	// has passed.
+	NewsStatusArchived StatusType = "archived"
+	NewsStatusDeleted  StatusType = "deleted"
+)
+
+// LanguageType is the Postgres text search configuration used to stem a
+// news item. It has to be one of the configurations listed in the
+// search_vector column of the news table.
+type LanguageType string
+
+const (
+	NewsLanguageSimple     LanguageType = "simple"
+	NewsLanguageEnglish    LanguageType = "english"
+	NewsLanguageIndonesian LanguageType = "indonesian"
 )
 
+var NewsLanguages = []LanguageType{
+	NewsLanguageSimple,
+	NewsLanguageEnglish,
+	NewsLanguageIndonesian,
+}
+
 type News struct {
 	common.Base
-	Title   string     `gorm:"type:varchar(255)" json:"title"`
-	Content string     `gorm:"type:text" json:"content"`
-	Status  StatusType `gorm:"type:varchar(50)" json:"status"`
-	Topics  []Topic    `gorm:"many2many:news_topics" json:"topics"`
+	Title    string       `gorm:"type:varchar(255)" json:"title"`
+	Content  string       `gorm:"type:text" json:"content"`
+	Status   StatusType   `gorm:"type:varchar(50)" json:"status"`
+	Language LanguageType `gorm:"type:varchar(20);default:simple" json:"language"`
+	Topics   []Topic      `gorm:"many2many:news_topics" json:"topics"`
+	// Authors is the byline in order. It is kept in news_authors, which
+	// carries the position, so it is loaded and saved by the repository
+	// rather than as a GORM association.
+	Authors []Author `gorm:"-" json:"authors"`
+	// Version is bumped on every write and served as the ETag.
+	Version int `gorm:"not null;default:1" json:"version"`
+	// PublishAt is when scheduled news goes live.
+	PublishAt *time.Time `json:"publish_at"`
+	// ExpiresAt is when published news is archived by the expirer worker.
+	ExpiresAt *time.Time `json:"expires_at"`
+	// EmbargoUntil hides the news from public reads until it passes. The
+	// embargo worker then clears it.
+	EmbargoUntil *time.Time `json:"embargo_until"`
 	gorm.Model
+
+	// Only filled when news is listed with a full-text search query.
+	SearchRank    float64 `gorm:"->;-:migration" json:"-"`
+	SearchTitle   string  `gorm:"->;-:migration" json:"-"`
+	SearchSnippet string  `gorm:"->;-:migration" json:"-"`
+
+	// Only filled when news is listed by topic with its pins first.
+	PinPosition *int `gorm:"->;-:migration" json:"-"`
+}
+
+// UnderEmbargo reports whether the news is still hidden from public reads
+// at now.
+func (n *News) UnderEmbargo(now time.Time) bool {
+	return n.EmbargoUntil != nil && n.EmbargoUntil.After(now)
 }
@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// NewsLock is an edit lock on a news item. It is a lease: the holder keeps
+// it alive with heartbeats, and once ExpiresAt has passed anyone can take
+// the lock over.
+type NewsLock struct {
+	NewsId     uint      `gorm:"primaryKey;autoIncrement:false" json:"news_id"`
+	Holder     string    `gorm:"type:varchar(255)" json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// HeldAt reports whether the lease is still running at now.
+func (l *NewsLock) HeldAt(now time.Time) bool {
+	return l.ExpiresAt.After(now)
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// NewsPendingEdit is the working copy of a published news item. It is
+// edited and previewed while the live news stays untouched, and replaces the
+// live content when it is published. A news item has at most one.
+type NewsPendingEdit struct {
+	NewsId   uint                `gorm:"primaryKey;autoIncrement:false" json:"news_id"`
+	Title    string              `gorm:"type:varchar(255)" json:"title"`
+	Content  string              `gorm:"type:text" json:"content"`
+	Language LanguageType        `gorm:"type:varchar(20)" json:"language"`
+	Topics   []NewsRevisionTopic `gorm:"type:text;serializer:json" json:"topics"`
+	// Version is bumped on every save of the working copy and served as its
+	// ETag, separately from the version of the live news.
+	Version   int       `gorm:"not null;default:1" json:"version"`
+	UpdatedBy string    `gorm:"type:varchar(255)" json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
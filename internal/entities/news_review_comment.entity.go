@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"news-topic-api/common"
+)
+
+// NewsReviewComment is a reviewer's note on a news item. Replies point at
+// the comment they answer through ParentId. A thread is resolved or
+// reopened as a whole through its root comment, which is also the only one
+// that can be anchored.
+type NewsReviewComment struct {
+	common.Base
+	NewsId   uint   `gorm:"not null" json:"news_id"`
+	ParentId *uint  `json:"parent_id"`
+	Author   string `gorm:"type:varchar(255)" json:"author"`
+	Body     string `gorm:"type:text" json:"body"`
+	// AnchorStart and AnchorEnd select the characters [start, end) of the
+	// news content the comment is about. AnchorText keeps those characters
+	// as they were, so the anchor still makes sense after the content
+	// changed.
+	AnchorStart *int       `json:"anchor_start"`
+	AnchorEnd   *int       `json:"anchor_end"`
+	AnchorText  string     `gorm:"type:text" json:"anchor_text"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	ResolvedBy  string     `gorm:"type:varchar(255)" json:"resolved_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Resolved reports whether the thread the comment starts is resolved.
+func (c *NewsReviewComment) Resolved() bool {
+	return c.ResolvedAt != nil
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"news-topic-api/common"
+)
+
+// NewsRevision is an immutable snapshot of a news item taken on every write.
+// Revisions are numbered from 1 per news item.
+type NewsRevision struct {
+	common.Base
+	NewsId    uint                `gorm:"not null;uniqueIndex:uni_news_revisions_news_revision" json:"news_id"`
+	Revision  int                 `gorm:"not null;uniqueIndex:uni_news_revisions_news_revision" json:"revision"`
+	Title     string              `gorm:"type:varchar(255)" json:"title"`
+	Content   string              `gorm:"type:text" json:"content"`
+	Status    StatusType          `gorm:"type:varchar(50)" json:"status"`
+	Language  LanguageType        `gorm:"type:varchar(20)" json:"language"`
+	Topics    []NewsRevisionTopic `gorm:"type:text;serializer:json" json:"topics"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+// NewsRevisionTopic is a topic as it was when the revision was taken.
+type NewsRevisionTopic struct {
+	Id    uint   `json:"id"`
+	UUID  string `json:"uuid"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,254 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTransition is wrapped by every TransitionError.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// TransitionError explains why news cannot move from one status to another.
+type TransitionError struct {
+	From   StatusType
+	To     StatusType
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == "" {
+		return fmt.Sprintf("news cannot be created as %s: %s", e.To, e.Reason)
+	}
+	return fmt.Sprintf("news cannot move from %s to %s: %s", e.From, e.To, e.Reason)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// TransitionContext carries what the guards need to know besides the news
+// item itself.
+type TransitionContext struct {
+	Now time.Time
+	// UnresolvedComments is the number of open review comment threads.
+	UnresolvedComments int
+}
+
+// transitionGuard returns why a transition is blocked, or "" when it is
+// allowed.
+type transitionGuard func(news *News, tc TransitionContext) string
+
+// newsTransitions is the editorial workflow: a draft is submitted for
+// review, approved, then published right away or scheduled. Cancelling a
+// schedule keeps the approval. Published news is archived when it is no
+// longer current. Anything can be deleted, and deleted news comes back from
+// the trash as a draft.
+var newsTransitions = map[StatusType]map[StatusType]transitionGuard{
+	NewsStatusDraft: {
+		NewsStatusInReview: requireContent,
+		NewsStatusDeleted:  nil,
+	},
+	NewsStatusInReview: {
+		NewsStatusDraft:    nil,
+		NewsStatusApproved: requireContent,
+		NewsStatusDeleted:  nil,
+	},
+	NewsStatusApproved: {
+		NewsStatusDraft:     nil,
+		NewsStatusPublished: allGuards(requireResolvedComments, requireUnexpired),
+		NewsStatusScheduled: allGuards(requireResolvedComments, requireFuturePublishAt),
+		NewsStatusDeleted:   nil,
+	},
+	NewsStatusScheduled: {
+		NewsStatusDraft:     nil,
+		NewsStatusApproved:  nil,
+		NewsStatusPublished: allGuards(requireResolvedComments, requireDuePublishAt, requireUnexpired),
+		NewsStatusDeleted:   nil,
+	},
+	NewsStatusPublished: {
+		NewsStatusArchived: nil,
+		NewsStatusDeleted:  nil,
+	},
+	NewsStatusArchived: {
+		NewsStatusPublished: allGuards(requireResolvedComments, requireUnexpired),
+		NewsStatusDeleted:   nil,
+	},
+	NewsStatusDeleted: {
+		NewsStatusDraft: nil,
+	},
+}
+
+// newsInitialStatuses are the statuses news can be created in, with the
+// guard each one needs.
+var newsInitialStatuses = map[StatusType]transitionGuard{
+	NewsStatusDraft:    nil,
+	NewsStatusInReview: requireContent,
+}
+
+// newsStatusOrder is the order transitions are listed in.
+var newsStatusOrder = []StatusType{
+	NewsStatusDraft,
+	NewsStatusInReview,
+	NewsStatusApproved,
+	NewsStatusScheduled,
+	NewsStatusPublished,
+	NewsStatusArchived,
+	NewsStatusDeleted,
+}
+
+// NewsTransition is a move from the current status of a news item, with the
+// reason it is blocked when a guard fails.
+type NewsTransition struct {
+	To      StatusType
+	Allowed bool
+	Reason  string
+}
+
+// CheckInitialStatus reports whether news may be created in its status.
+func (n *News) CheckInitialStatus(tc TransitionContext) error {
+	guard, ok := newsInitialStatuses[n.Status]
+	if !ok {
+		return &TransitionError{To: n.Status, Reason: "news starts as " + joinStatuses(initialStatuses())}
+	}
+
+	if reason := runGuard(guard, n, tc); reason != "" {
+		return &TransitionError{To: n.Status, Reason: reason}
+	}
+
+	return nil
+}
+
+// CheckTransition reports whether news may move from its current status to
+// status to.
+func (n *News) CheckTransition(to StatusType, tc TransitionContext) error {
+	if n.Status == to {
+		return &TransitionError{From: n.Status, To: to, Reason: "news is already " + string(to)}
+	}
+
+	next := newsTransitions[n.Status]
+	guard, ok := next[to]
+	if !ok {
+		return &TransitionError{From: n.Status, To: to, Reason: "allowed next statuses are " + joinStatuses(nextStatuses(next))}
+	}
+
+	if reason := runGuard(guard, n, tc); reason != "" {
+		return &TransitionError{From: n.Status, To: to, Reason: reason}
+	}
+
+	return nil
+}
+
+// Transitions lists every move out of the current status, evaluating the
+// guards so blocked ones carry their reason.
+func (n *News) Transitions(tc TransitionContext) []NewsTransition {
+	transitions := []NewsTransition{}
+	for _, to := range nextStatuses(newsTransitions[n.Status]) {
+		reason := runGuard(newsTransitions[n.Status][to], n, tc)
+		transitions = append(transitions, NewsTransition{
+			To:      to,
+			Allowed: reason == "",
+			Reason:  reason,
+		})
+	}
+	return transitions
+}
+
+func runGuard(guard transitionGuard, news *News, tc TransitionContext) string {
+	if guard == nil {
+		return ""
+	}
+	return guard(news, tc)
+}
+
+// allGuards runs guards in order and returns the first reason one of them
+// blocks the transition with.
+func allGuards(guards ...transitionGuard) transitionGuard {
+	return func(news *News, tc TransitionContext) string {
+		for _, guard := range guards {
+			if reason := runGuard(guard, news, tc); reason != "" {
+				return reason
+			}
+		}
+		return ""
+	}
+}
+
+func requireContent(news *News, _ TransitionContext) string {
+	if strings.TrimSpace(news.Title) == "" || strings.TrimSpace(news.Content) == "" {
+		return "title and content are required"
+	}
+	return ""
+}
+
+func requireFuturePublishAt(news *News, tc TransitionContext) string {
+	if news.PublishAt == nil {
+		return "publish_at is required, schedule through POST /news/{uuid}/schedule"
+	}
+	if !news.PublishAt.After(tc.Now) {
+		return "publish_at must be in the future"
+	}
+	return ""
+}
+
+func requireDuePublishAt(news *News, tc TransitionContext) string {
+	if news.PublishAt != nil && news.PublishAt.After(tc.Now) {
+		return "publish_at has not been reached, cancel the schedule to publish now"
+	}
+	return ""
+}
+
+// requireResolvedComments holds publishing back until every review thread
+// is resolved.
+func requireResolvedComments(_ *News, tc TransitionContext) string {
+	switch tc.UnresolvedComments {
+	case 0:
+		return ""
+	case 1:
+		return "1 review comment is unresolved"
+	default:
+		return fmt.Sprintf("%d review comments are unresolved", tc.UnresolvedComments)
+	}
+}
+
+// UnresolvedCommentsReason returns why open review threads hold publishing
+// back, or "" when every thread is resolved.
+func (tc TransitionContext) UnresolvedCommentsReason() string {
+	return requireResolvedComments(nil, tc)
+}
+
+// requireUnexpired keeps news from being published only to be archived by
+// the next expirer run.
+func requireUnexpired(news *News, tc TransitionContext) string {
+	if news.ExpiresAt != nil && !news.ExpiresAt.After(tc.Now) {
+		return "expires_at has passed, move or clear it through /news/{uuid}/expiry"
+	}
+	return ""
+}
+
+func nextStatuses(next map[StatusType]transitionGuard) []StatusType {
+	statuses := []StatusType{}
+	for _, status := range newsStatusOrder {
+		if _, ok := next[status]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func initialStatuses() []StatusType {
+	return nextStatuses(newsInitialStatuses)
+}
+
+func joinStatuses(statuses []StatusType) string {
+	if len(statuses) == 0 {
+		return "none"
+	}
+
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"news-topic-api/common"
+)
+
+// TopicAlias is another value a topic answers to in the topic filter of the
+// news listing, such as the value of a topic merged into it.
+type TopicAlias struct {
+	common.Base
+	TopicId   uint      `gorm:"not null" json:"topic_id"`
+	Value     string    `gorm:"type:varchar(255);not null" json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// TopicPin puts a news item at the top of a topic's listing. Pins of a
+// topic are ordered by Position, starting at 1.
+type TopicPin struct {
+	TopicId  uint `gorm:"primaryKey;autoIncrement:false" json:"topic_id"`
+	NewsId   uint `gorm:"primaryKey;autoIncrement:false" json:"news_id"`
+	Position int  `gorm:"not null" json:"position"`
+	// ExpiresAt unpins the news once it passes. Nil pins it until it is
+	// unpinned by hand.
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	News News `gorm:"foreignKey:NewsId;references:Id" json:"-"`
+}
+
+// ActiveAt reports whether the pin still applies at now.
+func (p *TopicPin) ActiveAt(now time.Time) bool {
+	return p.ExpiresAt == nil || p.ExpiresAt.After(now)
+}
@@ -11,5 +11,9 @@ type Topic struct {
 	Title string `gorm:"unique;type:varchar(255)" json:"title"`
 	Value string `gorm:"unique;type:varchar(255)" json:"value"`
 	News  []News `gorm:"many2many:news_topics;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"news"`
+	// ParentId places the topic under another one. Root topics have none.
+	ParentId *uint `json:"parent_id"`
+	// Version is bumped on every write and served as the ETag.
+	Version int `gorm:"not null;default:1" json:"version"`
 	gorm.Model
 }
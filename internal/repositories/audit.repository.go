@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	"news-topic-api/internal/entities"
+)
+
+type auditRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewAuditRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) AuditRepository {
+	return &auditRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *auditRepositoryGorm) CreateEvent(ctx context.Context, event *entities.AuditEvent) (*entities.AuditEvent, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (r *auditRepositoryGorm) GetEvents(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterAuditRequest) (events []*entities.AuditEvent, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := readDB(ctx, r.db, r.replicas).WithContext(ctx).Model(&entities.AuditEvent{})
+
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+	if filter.EntityUuid != nil {
+		query = query.Where("entity_uuid = ?", *filter.EntityUuid)
+	}
+	if filter.Actor != nil {
+		query = query.Where("actor = ?", *filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	err = query.Count(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("created_at desc, id desc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&events).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, items, nil
+}
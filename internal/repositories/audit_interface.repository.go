@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	"news-topic-api/internal/entities"
+)
+
+type AuditRepository interface {
+	CreateEvent(ctx context.Context, event *entities.AuditEvent) (*entities.AuditEvent, error)
+	// GetEvents lists the events matching filter, newest first.
+	GetEvents(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterAuditRequest) (events []*entities.AuditEvent, items int64, err error)
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/common"
+
+	"github.com/google/uuid"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	"news-topic-api/internal/entities"
+)
+
+type auditRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewAuditRepositoryMemory(store *MemoryStore) AuditRepository {
+	return &auditRepositoryMemory{store}
+}
+
+func (r *auditRepositoryMemory) CreateEvent(ctx context.Context, event *entities.AuditEvent) (*entities.AuditEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	r.store.lastAuditId++
+	event.Id = r.store.lastAuditId
+	event.UUID = uuid.NewString()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	c := *event
+	r.store.auditEvents = append(r.store.auditEvents, &c)
+
+	return event, nil
+}
+
+func (r *auditRepositoryMemory) GetEvents(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterAuditRequest) (events []*entities.AuditEvent, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.AuditEvent{}
+	for _, event := range r.store.auditEvents {
+		if filter.EntityType != nil && event.EntityType != *filter.EntityType {
+			continue
+		}
+		if filter.EntityUuid != nil && event.EntityUuid != *filter.EntityUuid {
+			continue
+		}
+		if filter.Actor != nil && event.Actor != *filter.Actor {
+			continue
+		}
+		if filter.From != nil && event.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !event.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		matched = append(matched, event)
+	}
+
+	sortByCreatedDesc(matched, func(e *entities.AuditEvent) (int64, uint) {
+		return e.CreatedAt.UnixNano(), e.Id
+	})
+
+	start, end := pageBounds(len(matched), pagination)
+
+	for _, event := range matched[start:end] {
+		c := *event
+		events = append(events, &c)
+	}
+
+	return events, int64(len(matched)), nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type authorRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewAuthorRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) AuthorRepository {
+	return &authorRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *authorRepositoryGorm) GetAuthors(ctx context.Context, pagination *common.Pagination) (authors []*entities.Author, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	db := readDB(ctx, r.db, r.replicas)
+
+	err = db.WithContext(ctx).Model(&entities.Author{}).
+		Count(&items).
+		Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = db.WithContext(ctx).Order("name, id").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&authors).
+		Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return authors, items, nil
+}
+
+func (r *authorRepositoryGorm) GetByUuid(ctx context.Context, uuid string) (*entities.Author, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var author *entities.Author
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).Find(&author, "uuid = ?", uuid)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("author not found")
+	}
+
+	return author, nil
+}
+
+func (r *authorRepositoryGorm) GetBySlug(ctx context.Context, slug string) (*entities.Author, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var authors []*entities.Author
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("slug = ?", slug).
+		Limit(1).
+		Find(&authors).Error
+
+	if err != nil || len(authors) == 0 {
+		return nil, err
+	}
+
+	return authors[0], nil
+}
+
+func (r *authorRepositoryGorm) CreateAuthor(ctx context.Context, author *entities.Author) (*entities.Author, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(author).Error; err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (r *authorRepositoryGorm) UpdateByUuid(ctx context.Context, uuid string, author *entities.Author) (*entities.Author, error) {
+	existing, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	expected := author.Version
+	author.Version = expected + 1
+
+	result := r.db.WithContext(ctx).Model(&entities.Author{}).
+		Where("id = ? AND version = ?", existing.Id, expected).
+		Updates(author)
+
+	if result.Error != nil {
+		author.Version = expected
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		author.Version = expected
+		return nil, common.ErrStaleVersion
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *authorRepositoryGorm) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&entities.Author{}, "uuid = ? AND version = ?", uuid, version)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return common.ErrStaleVersion
+	}
+
+	return nil
+}
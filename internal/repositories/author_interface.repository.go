@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/entities"
+)
+
+type AuthorRepository interface {
+	// GetAuthors lists authors by name.
+	GetAuthors(ctx context.Context, pagination *common.Pagination) (authors []*entities.Author, items int64, err error)
+	GetByUuid(ctx context.Context, uuid string) (*entities.Author, error)
+	// GetBySlug returns nil, without an error, when no author has the slug.
+	GetBySlug(ctx context.Context, slug string) (*entities.Author, error)
+	CreateAuthor(ctx context.Context, author *entities.Author) (*entities.Author, error)
+	// UpdateByUuid writes the non-zero fields of author as long as
+	// author.Version is still the current version, and bumps it. Otherwise
+	// it returns common.ErrStaleVersion.
+	UpdateByUuid(ctx context.Context, uuid string, author *entities.Author) (*entities.Author, error)
+	// DeleteByUuid soft deletes the author if version is still current. The
+	// author drops out of bylines and gives the slug up.
+	DeleteByUuid(ctx context.Context, uuid string, version int) error
+}
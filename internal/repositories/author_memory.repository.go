@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"news-topic-api/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type authorRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewAuthorRepositoryMemory(store *MemoryStore) AuthorRepository {
+	return &authorRepositoryMemory{store}
+}
+
+func (r *authorRepositoryMemory) GetAuthors(ctx context.Context, pagination *common.Pagination) (authors []*entities.Author, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.Author{}
+	for _, a := range r.store.authors {
+		if !a.DeletedAt.Valid {
+			matched = append(matched, a)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Name != matched[j].Name {
+			return matched[i].Name < matched[j].Name
+		}
+		return matched[i].Id < matched[j].Id
+	})
+
+	start, end := pageBounds(len(matched), pagination)
+
+	authors = []*entities.Author{}
+	for _, a := range matched[start:end] {
+		c := *a
+		authors = append(authors, &c)
+	}
+
+	return authors, int64(len(matched)), nil
+}
+
+func (r *authorRepositoryMemory) GetByUuid(ctx context.Context, uuid string) (*entities.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := r.store.findAuthor(uuid)
+	if existing == nil {
+		return nil, errors.New("author not found")
+	}
+
+	c := *existing
+	return &c, nil
+}
+
+func (r *authorRepositoryMemory) GetBySlug(ctx context.Context, slug string) (*entities.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, a := range r.store.authors {
+		if a.Slug == slug && !a.DeletedAt.Valid {
+			c := *a
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *authorRepositoryMemory) CreateAuthor(ctx context.Context, author *entities.Author) (*entities.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if err := r.checkUnique(0, author); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	r.store.lastAuthorId++
+	author.Id = r.store.lastAuthorId
+	author.ID = r.store.lastAuthorId
+	author.UUID = uuid.NewString()
+	author.Version = 1
+	author.CreatedAt = now
+	author.UpdatedAt = now
+
+	c := *author
+	r.store.authors = append(r.store.authors, &c)
+
+	return author, nil
+}
+
+func (r *authorRepositoryMemory) UpdateByUuid(ctx context.Context, uuid string, author *entities.Author) (*entities.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findAuthor(uuid)
+	if existing == nil {
+		return nil, errors.New("author not found")
+	}
+
+	if existing.Version != author.Version {
+		return nil, common.ErrStaleVersion
+	}
+
+	if err := r.checkUnique(existing.Id, author); err != nil {
+		return nil, err
+	}
+
+	if author.Name != "" {
+		existing.Name = author.Name
+	}
+	if author.Slug != "" {
+		existing.Slug = author.Slug
+	}
+	if author.Bio != "" {
+		existing.Bio = author.Bio
+	}
+	if author.AvatarURL != "" {
+		existing.AvatarURL = author.AvatarURL
+	}
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	c := *existing
+	return &c, nil
+}
+
+func (r *authorRepositoryMemory) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findAuthor(uuid)
+	if existing == nil || existing.Version != version {
+		return common.ErrStaleVersion
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// checkUnique mirrors the partial unique index on the slugs of authors
+// that are not deleted.
+func (r *authorRepositoryMemory) checkUnique(id uint, author *entities.Author) error {
+	for _, a := range r.store.authors {
+		if a.Id == id || a.DeletedAt.Valid {
+			continue
+		}
+		if author.Slug != "" && a.Slug == author.Slug {
+			return errors.New(`duplicate key value violates unique constraint "uni_authors_slug"`)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"news-topic-api/common"
+
+	"news-topic-api/internal/entities"
+)
+
+// MemoryStore keeps news, topics and their news_topics links in memory.
+// A single store is shared by the in-memory news and topic repositories so
+// that both sides of the many-to-many relation stay consistent.
+type MemoryStore struct {
+	// writeMu serialises writers and units of work, mu guards the data.
+	writeMu     sync.Mutex
+	mu          sync.RWMutex
+	news        []*entities.News
+	topics      []*entities.Topic
+	newsTopics  map[uint][]uint
+	revisions   []*entities.NewsRevision
+	lastNewsId  uint
+	lastTopicId uint
+	lastRevId   uint
+
+	// trashedNewsTopics holds the news ids a deleted topic was linked to,
+	// keyed by topic id, like the trashed_news_topics table.
+	trashedNewsTopics map[uint][]uint
+
+	auditEvents []*entities.AuditEvent
+	lastAuditId uint
+
+	reviewComments []*entities.NewsReviewComment
+	lastCommentId  uint
+
+	// newsLocks holds the edit lock of each locked news item, keyed by news
+	// id like the news_locks table.
+	newsLocks map[uint]*entities.NewsLock
+
+	// pendingEdits holds the working copy of published news, keyed by news
+	// id like the news_pending_edits table.
+	pendingEdits map[uint]*entities.NewsPendingEdit
+
+	authors      []*entities.Author
+	lastAuthorId uint
+	// newsAuthors holds the byline of each news item as author ids in
+	// order, like the news_authors table and its position column.
+	newsAuthors map[uint][]uint
+
+	// topicPins holds the pins of each topic, keyed by topic id like the
+	// topic_pins table.
+	topicPins map[uint][]*entities.TopicPin
+
+	topicAliases []*entities.TopicAlias
+	lastAliasId  uint
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		newsTopics:        map[uint][]uint{},
+		trashedNewsTopics: map[uint][]uint{},
+		newsLocks:         map[uint]*entities.NewsLock{},
+		pendingEdits:      map[uint]*entities.NewsPendingEdit{},
+		newsAuthors:       map[uint][]uint{},
+		topicPins:         map[uint][]*entities.TopicPin{},
+	}
+}
+
+func (s *MemoryStore) lock() {
+	s.writeMu.Lock()
+	s.mu.Lock()
+}
+
+func (s *MemoryStore) unlock() {
+	s.mu.Unlock()
+	s.writeMu.Unlock()
+}
+
+// clone returns a deep copy of the data, used as the working set of a unit
+// of work.
+func (s *MemoryStore) clone() *MemoryStore {
+	c := NewMemoryStore()
+	for _, n := range s.news {
+		c.news = append(c.news, copyNews(n))
+	}
+	for _, t := range s.topics {
+		topic := copyTopic(t)
+		c.topics = append(c.topics, &topic)
+	}
+	for newsId, topicIds := range s.newsTopics {
+		c.newsTopics[newsId] = append([]uint{}, topicIds...)
+	}
+	for topicId, newsIds := range s.trashedNewsTopics {
+		c.trashedNewsTopics[topicId] = append([]uint{}, newsIds...)
+	}
+	// revisions and audit events are never modified once written, sharing
+	// them is safe
+	c.revisions = append([]*entities.NewsRevision{}, s.revisions...)
+	c.auditEvents = append([]*entities.AuditEvent{}, s.auditEvents...)
+	// review comments are resolved and reopened in place
+	for _, comment := range s.reviewComments {
+		cc := *comment
+		c.reviewComments = append(c.reviewComments, &cc)
+	}
+	c.lastCommentId = s.lastCommentId
+	for newsId, lock := range s.newsLocks {
+		l := *lock
+		c.newsLocks[newsId] = &l
+	}
+	for newsId, edit := range s.pendingEdits {
+		c.pendingEdits[newsId] = copyPendingEdit(edit)
+	}
+	for _, author := range s.authors {
+		a := *author
+		c.authors = append(c.authors, &a)
+	}
+	c.lastAuthorId = s.lastAuthorId
+	for newsId, authorIds := range s.newsAuthors {
+		c.newsAuthors[newsId] = append([]uint{}, authorIds...)
+	}
+	for topicId, pins := range s.topicPins {
+		for _, pin := range pins {
+			p := *pin
+			c.topicPins[topicId] = append(c.topicPins[topicId], &p)
+		}
+	}
+	for _, alias := range s.topicAliases {
+		a := *alias
+		c.topicAliases = append(c.topicAliases, &a)
+	}
+	c.lastAliasId = s.lastAliasId
+	c.lastNewsId = s.lastNewsId
+	c.lastTopicId = s.lastTopicId
+	c.lastRevId = s.lastRevId
+	c.lastAuditId = s.lastAuditId
+	return c
+}
+
+// replace takes over the data of a committed unit of work.
+func (s *MemoryStore) replace(work *MemoryStore) {
+	s.news = work.news
+	s.topics = work.topics
+	s.newsTopics = work.newsTopics
+	s.revisions = work.revisions
+	s.trashedNewsTopics = work.trashedNewsTopics
+	s.auditEvents = work.auditEvents
+	s.lastAuditId = work.lastAuditId
+	s.reviewComments = work.reviewComments
+	s.lastCommentId = work.lastCommentId
+	s.newsLocks = work.newsLocks
+	s.pendingEdits = work.pendingEdits
+	s.authors = work.authors
+	s.lastAuthorId = work.lastAuthorId
+	s.newsAuthors = work.newsAuthors
+	s.topicPins = work.topicPins
+	s.topicAliases = work.topicAliases
+	s.lastAliasId = work.lastAliasId
+	s.lastNewsId = work.lastNewsId
+	s.lastTopicId = work.lastTopicId
+	s.lastRevId = work.lastRevId
+}
+
+func (s *MemoryStore) findNews(uuid string) *entities.News {
+	for _, n := range s.news {
+		if n.UUID == uuid && !n.DeletedAt.Valid {
+			return n
+		}
+	}
+	return nil
+}
+
+// findTrashedNews returns a soft-deleted news item, the row an Unscoped
+// query with "deleted_at IS NOT NULL" finds.
+func (s *MemoryStore) findTrashedNews(uuid string) *entities.News {
+	for _, n := range s.news {
+		if n.UUID == uuid && n.DeletedAt.Valid {
+			return n
+		}
+	}
+	return nil
+}
+
+// newsById returns a news item that is not deleted.
+func (s *MemoryStore) newsById(id uint) *entities.News {
+	for _, n := range s.news {
+		if n.Id == id && !n.DeletedAt.Valid {
+			return n
+		}
+	}
+	return nil
+}
+
+// activePin returns the position of a news item among the pins of the
+// topic with value that are still active at now.
+func (s *MemoryStore) activePin(value string, newsId uint, now time.Time) (int, bool) {
+	for _, t := range s.topics {
+		if !s.topicAnswersTo(t, value) {
+			continue
+		}
+		for _, pin := range s.topicPins[t.Id] {
+			if pin.NewsId == newsId && pin.ActiveAt(now) {
+				return pin.Position, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// topicAnswersTo reports whether value is the topic's value or one of its
+// aliases.
+func (s *MemoryStore) topicAnswersTo(t *entities.Topic, value string) bool {
+	if t.Value == value {
+		return true
+	}
+	for _, alias := range s.topicAliases {
+		if alias.TopicId == t.Id && alias.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryStore) findTrashedTopic(uuid string) *entities.Topic {
+	for _, t := range s.topics {
+		if t.UUID == uuid && t.DeletedAt.Valid {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) findTopic(uuid string) *entities.Topic {
+	for _, t := range s.topics {
+		if t.UUID == uuid && !t.DeletedAt.Valid {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) findAuthor(uuid string) *entities.Author {
+	for _, a := range s.authors {
+		if a.UUID == uuid && !a.DeletedAt.Valid {
+			return a
+		}
+	}
+	return nil
+}
+
+// authorsOf returns the byline of a news item in order, leaving out
+// deleted authors.
+func (s *MemoryStore) authorsOf(newsId uint) []entities.Author {
+	authors := []entities.Author{}
+	for _, id := range s.newsAuthors[newsId] {
+		for _, a := range s.authors {
+			if a.Id == id && !a.DeletedAt.Valid {
+				authors = append(authors, *a)
+			}
+		}
+	}
+	return authors
+}
+
+func (s *MemoryStore) topicById(id uint) *entities.Topic {
+	for _, t := range s.topics {
+		if t.Id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// linkTopic mirrors an insert into news_topics, ignoring pairs that
+// already exist just like the primary key on (topic_id, news_id).
+func (s *MemoryStore) linkTopic(newsId, topicId uint) {
+	for _, id := range s.newsTopics[newsId] {
+		if id == topicId {
+			return
+		}
+	}
+	s.newsTopics[newsId] = append(s.newsTopics[newsId], topicId)
+}
+
+// topicsOf returns the live topics linked to a news item, the same set a
+// GORM association query returns once soft-deleted topics are scoped out.
+func (s *MemoryStore) topicsOf(newsId uint) []entities.Topic {
+	topics := []entities.Topic{}
+	for _, id := range s.newsTopics[newsId] {
+		if t := s.topicById(id); t != nil && !t.DeletedAt.Valid {
+			topics = append(topics, copyTopic(t))
+		}
+	}
+	return topics
+}
+
+// hasUnresolvedComments reports whether a review thread on the news item is
+// still open.
+func (s *MemoryStore) hasUnresolvedComments(newsId uint) bool {
+	for _, comment := range s.reviewComments {
+		if comment.NewsId == newsId && comment.ParentId == nil && !comment.Resolved() {
+			return true
+		}
+	}
+	return false
+}
+
+// purgeNews removes a news item and, like the ON DELETE CASCADE foreign
+// keys, its topic links, topic pins, bylines, revisions, review comments,
+// edit lock and pending edit.
+func (s *MemoryStore) purgeNews(id uint) {
+	news := []*entities.News{}
+	for _, n := range s.news {
+		if n.Id != id {
+			news = append(news, n)
+		}
+	}
+	s.news = news
+
+	delete(s.newsTopics, id)
+	delete(s.newsAuthors, id)
+	for topicId, newsIds := range s.trashedNewsTopics {
+		s.trashedNewsTopics[topicId] = removeId(newsIds, id)
+	}
+	for topicId, pins := range s.topicPins {
+		kept := []*entities.TopicPin{}
+		for _, pin := range pins {
+			if pin.NewsId != id {
+				kept = append(kept, pin)
+			}
+		}
+		s.topicPins[topicId] = kept
+	}
+
+	revisions := []*entities.NewsRevision{}
+	for _, rev := range s.revisions {
+		if rev.NewsId != id {
+			revisions = append(revisions, rev)
+		}
+	}
+	s.revisions = revisions
+
+	comments := []*entities.NewsReviewComment{}
+	for _, comment := range s.reviewComments {
+		if comment.NewsId != id {
+			comments = append(comments, comment)
+		}
+	}
+	s.reviewComments = comments
+
+	delete(s.newsLocks, id)
+	delete(s.pendingEdits, id)
+}
+
+// purgeTopic removes a topic, its pins, its aliases and every link to it.
+func (s *MemoryStore) purgeTopic(id uint) {
+	topics := []*entities.Topic{}
+	for _, t := range s.topics {
+		if t.Id != id {
+			topics = append(topics, t)
+		}
+	}
+	s.topics = topics
+
+	for newsId, topicIds := range s.newsTopics {
+		s.newsTopics[newsId] = removeId(topicIds, id)
+	}
+	delete(s.trashedNewsTopics, id)
+	delete(s.topicPins, id)
+
+	aliases := []*entities.TopicAlias{}
+	for _, alias := range s.topicAliases {
+		if alias.TopicId != id {
+			aliases = append(aliases, alias)
+		}
+	}
+	s.topicAliases = aliases
+}
+
+func removeId(ids []uint, id uint) []uint {
+	kept := []uint{}
+	for _, i := range ids {
+		if i != id {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+func copyNews(n *entities.News) *entities.News {
+	c := *n
+	c.Topics = nil
+	c.Authors = nil
+	return &c
+}
+
+func copyTopic(t *entities.Topic) entities.Topic {
+	c := *t
+	c.News = nil
+	return c
+}
+
+// sortByCreatedDesc orders rows like "ORDER BY created_at desc", using the
+// id as a tie breaker so results are stable between calls. The trash
+// listings pass deleted_at as the key instead.
+func sortByCreatedDesc[T any](items []T, key func(T) (createdAt int64, id uint)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, ii := key(items[i])
+		cj, ij := key(items[j])
+		if ci != cj {
+			return ci > cj
+		}
+		return ii > ij
+	})
+}
+
+// keysetPage filters the rows after the cursor, which must already be in
+// "created_at desc, id desc" order, and sets the next cursor when rows
+// remain past the limit.
+func keysetPage[T any](items []T, pagination *common.Pagination, key func(T) (time.Time, uint)) []T {
+	page := []T{}
+	for _, item := range items {
+		createdAt, id := key(item)
+		if !pagination.Cursor.After(createdAt, id) {
+			continue
+		}
+		if len(page) == pagination.Limit {
+			last := page[len(page)-1]
+			createdAt, id := key(last)
+			pagination.NextCursor = &common.Cursor{CreatedAt: createdAt, Id: id}
+			break
+		}
+		page = append(page, item)
+	}
+	return page
+}
+
+// pageBounds returns the slice bounds selected by LIMIT/OFFSET.
+func pageBounds(total int, pagination *common.Pagination) (int, int) {
+	start := pagination.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if pagination.Limit >= 0 && start+pagination.Limit < total {
+		end = start + pagination.Limit
+	}
+
+	return start, end
+}
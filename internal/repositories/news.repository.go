@@ -1,48 +1,115 @@
 package repositories
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"news-topic-api/common"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"news-topic-api/internal/delivery/data/dtos"
 	"news-topic-api/internal/entities"
 )
 
 type newsRepositoryGorm struct {
-	db *gorm.DB
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
 }
 
-func NewNewsRepositoryGorm(db *gorm.DB) NewsRepository {
-	return &newsRepositoryGorm{db}
+func NewNewsRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) NewsRepository {
+	return &newsRepositoryGorm{db, replicas, timeouts}
 }
 
-func (r *newsRepositoryGorm) BeginTransaction() (*gorm.DB, error) {
-	return r.db.Begin(), nil
-}
-
-func (r *newsRepositoryGorm) CommitTransaction(tx *gorm.DB) error {
-	return tx.Commit().Error
-}
-
-func (r *newsRepositoryGorm) RollbackTransaction(tx *gorm.DB) error {
-	return tx.Rollback().Error
-}
+func (r *newsRepositoryGorm) GetNews(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*entities.News, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
 
-func (r *newsRepositoryGorm) GetNews(pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*entities.News, items int64, err error) {
-	query := r.db.Model(&entities.News{})
+	// one handle for the query and its subqueries, so a listing picks a
+	// replica once
+	db := readDB(ctx, r.db, r.replicas)
+	query := db.WithContext(ctx).Model(&entities.News{})
 
 	if filter.Title != nil {
-		query = query.Where("title ILIKE ?", "%"+*filter.Title+"%")
+		// LOWER ... LIKE instead of ILIKE so the filter also runs on SQLite
+		query = query.Where("LOWER(news.title) LIKE ?", "%"+strings.ToLower(*filter.Title)+"%")
 	}
 	if filter.Topic != nil {
-		query = query.Joins("JOIN news_topics nt ON nt.news_id = news.id").
+		values := filter.TopicValues
+		if len(values) == 0 {
+			values = []string{*filter.Topic}
+		}
+
+		// a subquery rather than a join, so news in several of the topics
+		// is listed once. A value also matches the topic it is an alias of.
+		query = query.Where("news.id IN (?)", db.Table("news_topics nt").
+			Select("nt.news_id").
 			Joins("JOIN topics t ON t.id = nt.topic_id").
-			Where("t.value = ?", filter.Topic)
+			Where("t.value IN ? OR t.id IN (SELECT topic_id FROM topic_aliases WHERE value IN ?)", values, values))
+
+		if filter.PinnedAt != nil {
+			query = query.Joins("LEFT JOIN topic_pins tp ON tp.news_id = news.id AND tp.topic_id IN (SELECT id FROM topics WHERE value = ? UNION SELECT topic_id FROM topic_aliases WHERE value = ?) AND (tp.expires_at IS NULL OR tp.expires_at > ?)", *filter.Topic, *filter.Topic, filter.PinnedAt.UTC())
+		}
+	}
+	if filter.Author != nil {
+		query = query.Joins("JOIN news_authors na ON na.news_id = news.id").
+			Joins("JOIN authors a ON a.id = na.author_id AND a.deleted_at IS NULL").
+			Where("a.slug = ?", filter.Author)
 	}
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
+	} else if !filter.IncludeArchived {
+		query = query.Where("news.status <> ?", entities.NewsStatusArchived)
+	}
+	if filter.VisibleAt != nil {
+		query = query.Where("news.embargo_until IS NULL OR news.embargo_until <= ?", filter.VisibleAt.UTC())
+	}
+
+	sqlite := isSQLite(r.db)
+
+	var tsquery string
+	var tsqueryArgs []interface{}
+	var terms []string
+	if filter.Query != nil && sqlite {
+		terms = searchTerms(*filter.Query)
+		if len(terms) == 0 {
+			query = query.Where("1 = 0")
+		}
+		for _, term := range terms {
+			query = query.Where("(LOWER(news.title) LIKE ? OR LOWER(news.content) LIKE ?)", "%"+term+"%", "%"+term+"%")
+		}
+	} else if filter.Query != nil {
+		tsquery, tsqueryArgs = searchTsquery(*filter.Query, filter.Language)
+		query = query.Where("news.search_vector @@ "+tsquery, tsqueryArgs...)
+	}
+
+	if pagination.Keyset {
+		if pagination.Cursor != nil {
+			query = query.Where("(news.created_at, news.id) < (?, ?)", pagination.Cursor.CreatedAt, pagination.Cursor.Id)
+		}
+
+		// one extra row tells whether there is a next page, counting is skipped
+		err = query.Select("news.*").
+			Order("news.created_at desc, news.id desc").
+			Preload("Topics").
+			Limit(pagination.Limit + 1).
+			Find(&news).Error
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(news) > pagination.Limit {
+			news = news[:pagination.Limit]
+			last := news[len(news)-1]
+			pagination.NextCursor = &common.Cursor{CreatedAt: last.CreatedAt, Id: last.Id}
+		}
+
+		return news, 0, nil
 	}
 
 	err = query.Count(&items).Error
@@ -50,7 +117,28 @@ func (r *newsRepositoryGorm) GetNews(pagination *common.Pagination, filter *dtos
 		return nil, 0, err
 	}
 
-	err = query.Order("created_at desc").
+	// an explicit select keeps GORM from listing the read-only search
+	// columns, which only exist in the search query, once a join is added
+	if filter.Query == nil && filter.Topic != nil && filter.PinnedAt != nil {
+		query = query.Select("news.*, tp.position AS pin_position").
+			Order("CASE WHEN tp.position IS NULL THEN 1 ELSE 0 END, tp.position")
+	} else if filter.Query == nil {
+		query = query.Select("news.*")
+	} else if sqlite {
+		rank, rankArgs := searchLikeRank(terms)
+		query = query.Select("news.*, "+rank+" AS search_rank", rankArgs...).Order("search_rank desc")
+	} else {
+		args := append(append(append([]interface{}{}, tsqueryArgs...), tsqueryArgs...), tsqueryArgs...)
+		query = query.Select(
+			"news.*, "+
+				"ts_rank_cd(news.search_vector, "+tsquery+") AS search_rank, "+
+				"ts_headline(news.language::regconfig, news.title, "+tsquery+", 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>') AS search_title, "+
+				"ts_headline(news.language::regconfig, news.content, "+tsquery+", 'MaxFragments=2, MaxWords=30, MinWords=10, StartSel=<mark>, StopSel=</mark>') AS search_snippet",
+			args...,
+		).Order("search_rank desc")
+	}
+
+	err = query.Order("news.created_at desc, news.id desc").
 		Preload("Topics").
 		Limit(pagination.Limit).
 		Offset(pagination.Offset).
@@ -60,11 +148,21 @@ func (r *newsRepositoryGorm) GetNews(pagination *common.Pagination, filter *dtos
 		return nil, 0, err
 	}
 
+	if filter.Query != nil && sqlite {
+		for _, n := range news {
+			n.SearchTitle = highlight(n.Title, terms)
+			n.SearchSnippet = highlight(snippet(n.Content, terms), terms)
+		}
+	}
+
 	return news, items, nil
 }
 
-func (r *newsRepositoryGorm) GetByUuid(uuid string) (news *entities.News, err error) {
-	result := r.db.Where("uuid = ?", uuid).First(&news)
+func (r *newsRepositoryGorm) GetByUuid(ctx context.Context, uuid string) (news *entities.News, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).Where("uuid = ?", uuid).First(&news)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -75,50 +173,508 @@ func (r *newsRepositoryGorm) GetByUuid(uuid string) (news *entities.News, err er
 	return news, nil
 }
 
-func (r *newsRepositoryGorm) CreateNews(news *entities.News) (*entities.News, error) {
-	result := r.db.Create(news)
+func (r *newsRepositoryGorm) CreateNews(ctx context.Context, news *entities.News) (*entities.News, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(news)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return news, nil
 }
 
-func (r *newsRepositoryGorm) UpdateByUuid(uuid string, news *entities.News) (*entities.News, error) {
-	existingNews, err := r.GetByUuid(uuid)
+func (r *newsRepositoryGorm) UpdateByUuid(ctx context.Context, uuid string, news *entities.News) (*entities.News, error) {
+	existingNews, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := r.db.Model(existingNews).Updates(news).Error; err != nil {
-		return nil, err
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	expected := news.Version
+	news.Version = expected + 1
+
+	// topics are changed through ReplaceTopics only
+	result := r.db.WithContext(ctx).Model(existingNews).
+		Where("version = ?", expected).
+		Omit(clause.Associations).
+		Updates(news)
+
+	if result.Error != nil {
+		news.Version = expected
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		news.Version = expected
+		return nil, common.ErrStaleVersion
 	}
 
 	return existingNews, nil
 }
 
-func (r *newsRepositoryGorm) DeleteByUuid(uuid string) error {
-	if err := r.db.Where("uuid = ?", uuid).Delete(&entities.News{}).Error; err != nil {
+func (r *newsRepositoryGorm) DeleteByUuid(ctx context.Context, uuid string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&entities.News{}).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *newsRepositoryGorm) UpdateNewsStatus(uuid string, dto dtos.UpdateNewsStatus) (*entities.News, error) {
-	existingNews, err := r.GetByUuid(uuid)
+func (r *newsRepositoryGorm) UpdateNewsStatus(ctx context.Context, uuid string, version int, dto dtos.UpdateNewsStatus) (*entities.News, error) {
+	existingNews, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(existingNews).
+		Where("version = ?", version).
+		Updates(map[string]interface{}{
+			"status":  dto.Status,
+			"version": version + 1,
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
 	existingNews.Status = entities.StatusType(dto.Status)
+	existingNews.Version = version + 1
+
+	return existingNews, nil
+}
 
-	if err := r.db.Save(existingNews).Error; err != nil {
+func (r *newsRepositoryGorm) UpdateSchedule(ctx context.Context, uuid string, version int, status entities.StatusType, publishAt *time.Time) (*entities.News, error) {
+	existingNews, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+	if err != nil {
 		return nil, err
 	}
 
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(existingNews).
+		Where("version = ?", version).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"publish_at": publishAt,
+			"version":    version + 1,
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	existingNews.Status = status
+	existingNews.PublishAt = publishAt
+	existingNews.Version = version + 1
+
+	return existingNews, nil
+}
+
+func (r *newsRepositoryGorm) GetDueScheduled(ctx context.Context, now time.Time, limit int) (news []*entities.News, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	// the primary, a lagging replica could hand out items already published
+	err = r.db.WithContext(ctx).
+		Where("status = ? AND publish_at <= ?", entities.NewsStatusScheduled, now).
+		Where("NOT EXISTS (SELECT 1 FROM news_review_comments c WHERE c.news_id = news.id AND c.parent_id IS NULL AND c.resolved_at IS NULL)").
+		Order("publish_at, id").
+		Limit(limit).
+		Find(&news).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+func (r *newsRepositoryGorm) PublishScheduled(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	// the status check in the WHERE clause is the claim: a second publisher
+	// waits for the first one's row lock and then matches nothing
+	result := r.db.WithContext(writeCtx).Model(&entities.News{}).
+		Where("uuid = ? AND status = ? AND publish_at <= ?", uuid, entities.NewsStatusScheduled, now).
+		Updates(map[string]interface{}{
+			"status":  entities.NewsStatusPublished,
+			"version": gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *newsRepositoryGorm) UpdateExpiry(ctx context.Context, uuid string, version int, status entities.StatusType, expiresAt *time.Time) (*entities.News, error) {
+	existingNews, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(existingNews).
+		Where("version = ?", version).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"expires_at": expiresAt,
+			"version":    version + 1,
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	existingNews.Status = status
+	existingNews.ExpiresAt = expiresAt
+	existingNews.Version = version + 1
+
+	return existingNews, nil
+}
+
+func (r *newsRepositoryGorm) GetDueExpired(ctx context.Context, now time.Time, limit int) (news []*entities.News, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	// the primary, for the same reason as GetDueScheduled
+	err = r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", entities.NewsStatusPublished, now).
+		Order("expires_at, id").
+		Limit(limit).
+		Find(&news).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+func (r *newsRepositoryGorm) ArchiveExpired(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(writeCtx).Model(&entities.News{}).
+		Where("uuid = ? AND status = ? AND expires_at <= ?", uuid, entities.NewsStatusPublished, now).
+		Updates(map[string]interface{}{
+			"status":  entities.NewsStatusArchived,
+			"version": gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *newsRepositoryGorm) UpdateEmbargo(ctx context.Context, uuid string, version int, embargoUntil *time.Time) (*entities.News, error) {
+	existingNews, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(existingNews).
+		Where("version = ?", version).
+		Updates(map[string]interface{}{
+			"embargo_until": embargoUntil,
+			"version":       version + 1,
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	existingNews.EmbargoUntil = embargoUntil
+	existingNews.Version = version + 1
+
 	return existingNews, nil
 }
 
-func (r *newsRepositoryGorm) LoadTopics(news *entities.News) error {
-	return r.db.Model(news).Association("Topics").Find(&news.Topics)
+func (r *newsRepositoryGorm) GetDueEmbargoes(ctx context.Context, now time.Time, limit int) (news []*entities.News, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	// the primary, for the same reason as GetDueScheduled
+	err = r.db.WithContext(ctx).
+		Where("embargo_until <= ?", now).
+		Order("embargo_until, id").
+		Limit(limit).
+		Find(&news).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+func (r *newsRepositoryGorm) LiftDueEmbargo(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(writeCtx).Model(&entities.News{}).
+		Where("uuid = ? AND embargo_until <= ?", uuid, now).
+		Updates(map[string]interface{}{
+			"embargo_until": nil,
+			"version":       gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *newsRepositoryGorm) LoadTopics(ctx context.Context, news *entities.News) error {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	return readDB(ctx, r.db, r.replicas).WithContext(ctx).Model(news).Association("Topics").Find(&news.Topics)
+}
+
+func (r *newsRepositoryGorm) ReplaceTopics(ctx context.Context, news *entities.News, topics []entities.Topic) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(news).Association("Topics").Replace(topics)
+}
+
+func (r *newsRepositoryGorm) LoadAuthors(ctx context.Context, news ...*entities.News) error {
+	if len(news) == 0 {
+		return nil
+	}
+
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	byId := map[uint]*entities.News{}
+	ids := make([]uint, len(news))
+	for i, n := range news {
+		n.Authors = []entities.Author{}
+		byId[n.Id] = n
+		ids[i] = n.Id
+	}
+
+	var rows []struct {
+		NewsId uint
+		entities.Author
+	}
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Table("news_authors na").
+		Select("na.news_id, a.*").
+		Joins("JOIN authors a ON a.id = na.author_id AND a.deleted_at IS NULL").
+		Where("na.news_id IN ?", ids).
+		Order("na.news_id, na.position").
+		Scan(&rows).Error
+
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		n := byId[row.NewsId]
+		n.Authors = append(n.Authors, row.Author)
+	}
+
+	return nil
+}
+
+func (r *newsRepositoryGorm) ReplaceAuthors(ctx context.Context, news *entities.News, authors []entities.Author) error {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	err := r.db.WithContext(writeCtx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("news_id = ?", news.Id).Delete(&entities.NewsAuthor{}).Error; err != nil {
+			return err
+		}
+
+		if len(authors) == 0 {
+			return nil
+		}
+
+		links := make([]entities.NewsAuthor, len(authors))
+		for i, author := range authors {
+			links[i] = entities.NewsAuthor{NewsId: news.Id, AuthorId: author.Id, Position: i}
+		}
+
+		return tx.Create(&links).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.LoadAuthors(common.WithPrimaryReads(ctx), news)
+}
+
+func (r *newsRepositoryGorm) GetEmbargoedUuids(ctx context.Context, uuids []string, now time.Time) ([]string, error) {
+	embargoed := []string{}
+	if len(uuids) == 0 {
+		return embargoed, nil
+	}
+
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).Unscoped().Model(&entities.News{}).
+		Where("uuid IN ? AND embargo_until > ?", uuids, now.UTC()).
+		Pluck("uuid", &embargoed).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return embargoed, nil
+}
+
+func (r *newsRepositoryGorm) GetTrashed(ctx context.Context, pagination *common.Pagination, visibleAt *time.Time) (news []*entities.News, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := readDB(ctx, r.db, r.replicas).WithContext(ctx).Unscoped().Model(&entities.News{}).Where("deleted_at IS NOT NULL")
+
+	if visibleAt != nil {
+		query = query.Where("embargo_until IS NULL OR embargo_until <= ?", visibleAt.UTC())
+	}
+
+	if err := query.Count(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("deleted_at desc, id desc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&news).
+		Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return news, items, nil
+}
+
+func (r *newsRepositoryGorm) GetTrashedByUuid(ctx context.Context, uuid string) (*entities.News, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var news entities.News
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).Unscoped().
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		First(&news).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &news, nil
+}
+
+func (r *newsRepositoryGorm) RestoreByUuid(ctx context.Context, uuid string) (*entities.News, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(writeCtx).Unscoped().Model(&entities.News{}).
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *newsRepositoryGorm) PurgeByUuid(ctx context.Context, uuid string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	// news_topics, trashed_news_topics, topic_pins, news_authors,
+	// news_revisions, news_review_comments, news_locks and
+	// news_pending_edits rows go with the news row through their ON DELETE
+	// CASCADE foreign keys
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		Delete(&entities.News{})
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// searchLikeRank scores news like searchRank does for the in-memory backend:
+// the occurrences of each term in the title, plus 0.4 per occurrence in the
+// content, averaged over the terms.
+func searchLikeRank(terms []string) (string, []interface{}) {
+	occurrences := "(length(lower(%[1]s)) - length(replace(lower(%[1]s), ?, ''))) * 1.0 / length(?)"
+
+	parts := []string{}
+	args := []interface{}{}
+	for _, term := range terms {
+		parts = append(parts, fmt.Sprintf(occurrences, "news.title")+" + 0.4 * "+fmt.Sprintf(occurrences, "news.content"))
+		args = append(args, term, term, term, term)
+	}
+
+	return fmt.Sprintf("((%s) / %d)", strings.Join(parts, " + "), len(terms)), args
+}
+
+// searchTsquery builds the tsquery for a search. Without a language the
+// query is stemmed with every supported configuration, so each news item
+// matches on the configuration it was indexed with.
+func searchTsquery(q string, language *string) (string, []interface{}) {
+	if language != nil {
+		return "websearch_to_tsquery(?::regconfig, ?)", []interface{}{*language, q}
+	}
+
+	parts := []string{}
+	args := []interface{}{}
+	for _, lang := range entities.NewsLanguages {
+		parts = append(parts, "websearch_to_tsquery(?::regconfig, ?)")
+		args = append(args, string(lang), q)
+	}
+
+	return "(" + strings.Join(parts, " || ") + ")", args
 }
@@ -1,25 +1,85 @@
 package repositories
 
 import (
+	"context"
 	"news-topic-api/common"
-
-	"gorm.io/gorm"
+	"time"
 
 	"news-topic-api/internal/delivery/data/dtos"
 	"news-topic-api/internal/entities"
 )
 
 type NewsRepository interface {
-	BeginTransaction() (*gorm.DB, error)
-	CommitTransaction(tx *gorm.DB) error
-	RollbackTransaction(tx *gorm.DB) error
-
-	GetNews(pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*entities.News, items int64, err error)
-	GetByUuid(uuid string) (*entities.News, error)
-	CreateNews(news *entities.News) (*entities.News, error)
-	UpdateByUuid(uuid string, news *entities.News) (*entities.News, error)
-	DeleteByUuid(uuid string) error
-	UpdateNewsStatus(uuid string, dto dtos.UpdateNewsStatus) (*entities.News, error)
-
-	LoadTopics(news *entities.News) error
+	GetNews(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*entities.News, items int64, err error)
+	GetByUuid(ctx context.Context, uuid string) (*entities.News, error)
+	CreateNews(ctx context.Context, news *entities.News) (*entities.News, error)
+	// UpdateByUuid writes the non-zero fields of news as long as news.Version
+	// is still the current version, and bumps it. Otherwise it returns
+	// common.ErrStaleVersion.
+	UpdateByUuid(ctx context.Context, uuid string, news *entities.News) (*entities.News, error)
+	DeleteByUuid(ctx context.Context, uuid string) error
+	// UpdateNewsStatus changes the status under the same version check as
+	// UpdateByUuid.
+	UpdateNewsStatus(ctx context.Context, uuid string, version int, dto dtos.UpdateNewsStatus) (*entities.News, error)
+	// UpdateSchedule sets the status and publish time under the same
+	// version check as UpdateByUuid. A nil publishAt clears it.
+	UpdateSchedule(ctx context.Context, uuid string, version int, status entities.StatusType, publishAt *time.Time) (*entities.News, error)
+
+	// GetDueScheduled lists up to limit scheduled news whose publish time is
+	// at or before now, earliest first. News with an unresolved review
+	// thread cannot be published and is left out.
+	GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]*entities.News, error)
+	// PublishScheduled publishes a news item only if it is still scheduled
+	// and due at now, so concurrent publishers cannot both claim it. It
+	// returns common.ErrStaleVersion when the item was already published,
+	// rescheduled or cancelled.
+	PublishScheduled(ctx context.Context, uuid string, now time.Time) (*entities.News, error)
+
+	// UpdateExpiry sets the status and expiry time under the same version
+	// check as UpdateByUuid. A nil expiresAt clears it.
+	UpdateExpiry(ctx context.Context, uuid string, version int, status entities.StatusType, expiresAt *time.Time) (*entities.News, error)
+
+	// GetDueExpired lists up to limit published news whose expiry time is at
+	// or before now, earliest first.
+	GetDueExpired(ctx context.Context, now time.Time, limit int) ([]*entities.News, error)
+	// ArchiveExpired archives a news item only if it is still published and
+	// expired at now, claiming it like PublishScheduled does.
+	ArchiveExpired(ctx context.Context, uuid string, now time.Time) (*entities.News, error)
+
+	// UpdateEmbargo sets the embargo time under the same version check as
+	// UpdateByUuid. A nil embargoUntil lifts the embargo.
+	UpdateEmbargo(ctx context.Context, uuid string, version int, embargoUntil *time.Time) (*entities.News, error)
+
+	// GetDueEmbargoes lists up to limit news whose embargo time is at or
+	// before now, earliest first.
+	GetDueEmbargoes(ctx context.Context, now time.Time, limit int) ([]*entities.News, error)
+	// LiftDueEmbargo clears the embargo of a news item only if it has still
+	// passed at now, claiming it like PublishScheduled does.
+	LiftDueEmbargo(ctx context.Context, uuid string, now time.Time) (*entities.News, error)
+
+	// GetEmbargoedUuids returns those of uuids whose news, trashed or not,
+	// is under embargo at now.
+	GetEmbargoedUuids(ctx context.Context, uuids []string, now time.Time) ([]string, error)
+
+	LoadTopics(ctx context.Context, news *entities.News) error
+	ReplaceTopics(ctx context.Context, news *entities.News, topics []entities.Topic) error
+
+	// LoadAuthors fills the bylines of news in position order, leaving out
+	// deleted authors.
+	LoadAuthors(ctx context.Context, news ...*entities.News) error
+	// ReplaceAuthors sets the byline of a news item to authors, in the
+	// order given.
+	ReplaceAuthors(ctx context.Context, news *entities.News, authors []entities.Author) error
+
+	// GetTrashed lists soft-deleted news, most recently deleted first. A
+	// non-nil visibleAt leaves out news under embargo at that time.
+	GetTrashed(ctx context.Context, pagination *common.Pagination, visibleAt *time.Time) (news []*entities.News, items int64, err error)
+	// GetTrashedByUuid returns a soft-deleted news item, or
+	// gorm.ErrRecordNotFound when there is no such item in the trash.
+	GetTrashedByUuid(ctx context.Context, uuid string) (*entities.News, error)
+	RestoreByUuid(ctx context.Context, uuid string) (*entities.News, error)
+	// PurgeByUuid permanently deletes a soft-deleted news item together with
+	// its topic links, bylines, revisions, review comments, edit lock and
+	// pending edit.
+	PurgeByUuid(ctx context.Context, uuid string) error
 }
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsLockRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewNewsLockRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) NewsLockRepository {
+	return &newsLockRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *newsLockRepositoryGorm) GetLock(ctx context.Context, newsId uint) (*entities.NewsLock, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var locks []*entities.NewsLock
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("news_id = ?", newsId).
+		Limit(1).
+		Find(&locks).Error
+
+	if err != nil || len(locks) == 0 {
+		return nil, err
+	}
+
+	return locks[0], nil
+}
+
+func (r *newsLockRepositoryGorm) AcquireLock(ctx context.Context, lock *entities.NewsLock, now time.Time) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	// the upsert only overwrites a lock that is the caller's own or has run
+	// out, otherwise it touches no row
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "news_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"holder", "acquired_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("news_locks.holder = excluded.holder OR news_locks.expires_at <= ?", now),
+		}},
+	}).Create(lock)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *newsLockRepositoryGorm) RenewLock(ctx context.Context, newsId uint, holder string, expiresAt time.Time, now time.Time) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&entities.NewsLock{}).
+		Where("news_id = ? AND holder = ? AND expires_at > ?", newsId, holder, now).
+		Update("expires_at", expiresAt)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *newsLockRepositoryGorm) ReleaseLock(ctx context.Context, newsId uint, holder string) (bool, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Where("news_id = ?", newsId)
+	if holder != "" {
+		query = query.Where("holder = ?", holder)
+	}
+
+	result := query.Delete(&entities.NewsLock{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/entities"
+)
+
+type NewsLockRepository interface {
+	// GetLock returns the lock on a news item, expired or not, or nil when
+	// it has none.
+	GetLock(ctx context.Context, newsId uint) (*entities.NewsLock, error)
+	// AcquireLock stores lock unless another holder's lease is still running
+	// at now, in which case it returns false. Taking a lock and the check
+	// happen in one statement, so two callers cannot both win.
+	AcquireLock(ctx context.Context, lock *entities.NewsLock, now time.Time) (bool, error)
+	// RenewLock moves the end of holder's running lease to expiresAt and
+	// returns false when holder has no running lease.
+	RenewLock(ctx context.Context, newsId uint, holder string, expiresAt time.Time, now time.Time) (bool, error)
+	// ReleaseLock removes the lock. An empty holder removes it whoever holds
+	// it. It returns false when there was nothing to remove.
+	ReleaseLock(ctx context.Context, newsId uint, holder string) (bool, error)
+}
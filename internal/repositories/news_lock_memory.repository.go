@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsLockRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewNewsLockRepositoryMemory(store *MemoryStore) NewsLockRepository {
+	return &newsLockRepositoryMemory{store}
+}
+
+func (r *newsLockRepositoryMemory) GetLock(ctx context.Context, newsId uint) (*entities.NewsLock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	lock, ok := r.store.newsLocks[newsId]
+	if !ok {
+		return nil, nil
+	}
+
+	c := *lock
+	return &c, nil
+}
+
+func (r *newsLockRepositoryMemory) AcquireLock(ctx context.Context, lock *entities.NewsLock, now time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if existing, ok := r.store.newsLocks[lock.NewsId]; ok && existing.Holder != lock.Holder && existing.HeldAt(now) {
+		return false, nil
+	}
+
+	c := *lock
+	r.store.newsLocks[lock.NewsId] = &c
+
+	return true, nil
+}
+
+func (r *newsLockRepositoryMemory) RenewLock(ctx context.Context, newsId uint, holder string, expiresAt time.Time, now time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing, ok := r.store.newsLocks[newsId]
+	if !ok || existing.Holder != holder || !existing.HeldAt(now) {
+		return false, nil
+	}
+
+	existing.ExpiresAt = expiresAt
+
+	return true, nil
+}
+
+func (r *newsLockRepositoryMemory) ReleaseLock(ctx context.Context, newsId uint, holder string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing, ok := r.store.newsLocks[newsId]
+	if !ok || (holder != "" && existing.Holder != holder) {
+		return false, nil
+	}
+
+	delete(r.store.newsLocks, newsId)
+
+	return true, nil
+}
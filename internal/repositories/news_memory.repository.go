@@ -0,0 +1,704 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"news-topic-api/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	"news-topic-api/internal/entities"
+)
+
+type newsRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewNewsRepositoryMemory(store *MemoryStore) NewsRepository {
+	return &newsRepositoryMemory{store}
+}
+
+func (r *newsRepositoryMemory) GetNews(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*entities.News, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var terms []string
+	if filter.Query != nil {
+		terms = searchTerms(*filter.Query)
+	}
+
+	matched := []*entities.News{}
+	ranks := map[uint]float64{}
+	pins := map[uint]int{}
+	for _, n := range r.store.news {
+		if n.DeletedAt.Valid {
+			continue
+		}
+		if filter.Title != nil && !strings.Contains(strings.ToLower(n.Title), strings.ToLower(*filter.Title)) {
+			continue
+		}
+		if filter.Topic != nil && !r.hasTopicValue(n.Id, filter) {
+			continue
+		}
+		if filter.Author != nil && !r.hasAuthorSlug(n.Id, *filter.Author) {
+			continue
+		}
+		if filter.Status != nil && string(n.Status) != *filter.Status {
+			continue
+		}
+		if filter.Status == nil && !filter.IncludeArchived && n.Status == entities.NewsStatusArchived {
+			continue
+		}
+		if filter.VisibleAt != nil && n.UnderEmbargo(*filter.VisibleAt) {
+			continue
+		}
+		if filter.Query != nil {
+			rank, ok := searchRank(n, terms)
+			if !ok {
+				continue
+			}
+			ranks[n.Id] = rank
+		}
+		if filter.Topic != nil && filter.PinnedAt != nil {
+			if position, ok := r.store.activePin(*filter.Topic, n.Id, *filter.PinnedAt); ok {
+				pins[n.Id] = position
+			}
+		}
+		matched = append(matched, n)
+	}
+
+	sortByCreatedDesc(matched, func(n *entities.News) (int64, uint) {
+		return n.CreatedAt.UnixNano(), n.Id
+	})
+	if filter.Query != nil {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return ranks[matched[i].Id] > ranks[matched[j].Id]
+		})
+	} else if len(pins) > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			pi, iPinned := pins[matched[i].Id]
+			pj, jPinned := pins[matched[j].Id]
+			if iPinned != jPinned {
+				return iPinned
+			}
+			return pi < pj
+		})
+	}
+
+	page := matched
+	if pagination.Keyset {
+		page = keysetPage(matched, pagination, func(n *entities.News) (time.Time, uint) {
+			return n.CreatedAt, n.Id
+		})
+		items = 0
+	} else {
+		start, end := pageBounds(len(matched), pagination)
+		page = matched[start:end]
+		items = int64(len(matched))
+	}
+
+	news = []*entities.News{}
+	for _, n := range page {
+		c := copyNews(n)
+		c.Topics = r.store.topicsOf(n.Id)
+		if filter.Query != nil {
+			c.SearchRank = ranks[n.Id]
+			c.SearchTitle = highlight(n.Title, terms)
+			c.SearchSnippet = highlight(snippet(n.Content, terms), terms)
+		}
+		if position, ok := pins[n.Id]; ok {
+			c.PinPosition = &position
+		}
+		news = append(news, c)
+	}
+
+	return news, items, nil
+}
+
+// hasTopicValue follows the raw subquery used by the GORM filter, which does
+// not scope out soft-deleted topics and also matches topic aliases.
+func (r *newsRepositoryMemory) hasTopicValue(newsId uint, filter *dtos.FilterNewsRequest) bool {
+	values := filter.TopicValues
+	if len(values) == 0 {
+		values = []string{*filter.Topic}
+	}
+
+	for _, id := range r.store.newsTopics[newsId] {
+		t := r.store.topicById(id)
+		if t == nil {
+			continue
+		}
+		for _, value := range values {
+			if r.store.topicAnswersTo(t, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *newsRepositoryMemory) hasAuthorSlug(newsId uint, slug string) bool {
+	for _, a := range r.store.authorsOf(newsId) {
+		if a.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *newsRepositoryMemory) GetByUuid(ctx context.Context, uuid string) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) CreateNews(ctx context.Context, news *entities.News) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	now := time.Now()
+
+	r.store.lastNewsId++
+	news.Id = r.store.lastNewsId
+	news.ID = r.store.lastNewsId
+	news.UUID = uuid.NewString()
+	news.CreatedAt = now
+	news.UpdatedAt = now
+	if news.Language == "" {
+		news.Language = entities.NewsLanguageSimple
+	}
+	if news.Version == 0 {
+		news.Version = 1
+	}
+
+	r.store.news = append(r.store.news, copyNews(news))
+	for _, topic := range news.Topics {
+		if r.store.topicById(topic.Id) != nil {
+			r.store.linkTopic(news.Id, topic.Id)
+		}
+	}
+
+	return news, nil
+}
+
+func (r *newsRepositoryMemory) UpdateByUuid(ctx context.Context, uuid string, news *entities.News) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if existing.Version != news.Version {
+		return nil, common.ErrStaleVersion
+	}
+
+	// Only non-zero fields are written, matching GORM's Updates with a struct.
+	if news.Title != "" {
+		existing.Title = news.Title
+	}
+	if news.Content != "" {
+		existing.Content = news.Content
+	}
+	if news.Status != "" {
+		existing.Status = news.Status
+	}
+	if news.Language != "" {
+		existing.Language = news.Language
+	}
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	updated := copyNews(existing)
+	updated.Topics = r.store.topicsOf(existing.Id)
+
+	return updated, nil
+}
+
+func (r *newsRepositoryMemory) DeleteByUuid(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if existing := r.store.findNews(uuid); existing != nil {
+		existing.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	}
+
+	return nil
+}
+
+func (r *newsRepositoryMemory) UpdateNewsStatus(ctx context.Context, uuid string, version int, dto dtos.UpdateNewsStatus) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if existing.Version != version {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.Status = entities.StatusType(dto.Status)
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) UpdateSchedule(ctx context.Context, uuid string, version int, status entities.StatusType, publishAt *time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if existing.Version != version {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.Status = status
+	existing.PublishAt = publishAt
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	due := []*entities.News{}
+	for _, n := range r.store.news {
+		if isDue(n, now) && !r.store.hasUnresolvedComments(n.Id) {
+			due = append(due, copyNews(n))
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].PublishAt.Equal(*due[j].PublishAt) {
+			return due[i].PublishAt.Before(*due[j].PublishAt)
+		}
+		return due[i].Id < due[j].Id
+	})
+
+	if limit >= 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *newsRepositoryMemory) PublishScheduled(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil || !isDue(existing, now) {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.Status = entities.NewsStatusPublished
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+// isDue mirrors "status = 'scheduled' AND publish_at <= now" on a live row.
+func isDue(n *entities.News, now time.Time) bool {
+	return !n.DeletedAt.Valid &&
+		n.Status == entities.NewsStatusScheduled &&
+		n.PublishAt != nil &&
+		!n.PublishAt.After(now)
+}
+
+func (r *newsRepositoryMemory) UpdateExpiry(ctx context.Context, uuid string, version int, status entities.StatusType, expiresAt *time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if existing.Version != version {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.Status = status
+	existing.ExpiresAt = expiresAt
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) GetDueExpired(ctx context.Context, now time.Time, limit int) ([]*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	due := []*entities.News{}
+	for _, n := range r.store.news {
+		if isExpired(n, now) {
+			due = append(due, copyNews(n))
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].ExpiresAt.Equal(*due[j].ExpiresAt) {
+			return due[i].ExpiresAt.Before(*due[j].ExpiresAt)
+		}
+		return due[i].Id < due[j].Id
+	})
+
+	if limit >= 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *newsRepositoryMemory) ArchiveExpired(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil || !isExpired(existing, now) {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.Status = entities.NewsStatusArchived
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+// isExpired mirrors "status = 'published' AND expires_at <= now" on a live
+// row.
+func isExpired(n *entities.News, now time.Time) bool {
+	return !n.DeletedAt.Valid &&
+		n.Status == entities.NewsStatusPublished &&
+		n.ExpiresAt != nil &&
+		!n.ExpiresAt.After(now)
+}
+
+func (r *newsRepositoryMemory) UpdateEmbargo(ctx context.Context, uuid string, version int, embargoUntil *time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if existing.Version != version {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.EmbargoUntil = embargoUntil
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) GetDueEmbargoes(ctx context.Context, now time.Time, limit int) ([]*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	due := []*entities.News{}
+	for _, n := range r.store.news {
+		if isEmbargoLifted(n, now) {
+			due = append(due, copyNews(n))
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].EmbargoUntil.Equal(*due[j].EmbargoUntil) {
+			return due[i].EmbargoUntil.Before(*due[j].EmbargoUntil)
+		}
+		return due[i].Id < due[j].Id
+	})
+
+	if limit >= 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *newsRepositoryMemory) LiftDueEmbargo(ctx context.Context, uuid string, now time.Time) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findNews(uuid)
+	if existing == nil || !isEmbargoLifted(existing, now) {
+		return nil, common.ErrStaleVersion
+	}
+
+	existing.EmbargoUntil = nil
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+// isEmbargoLifted mirrors "embargo_until <= now" on a live row.
+func isEmbargoLifted(n *entities.News, now time.Time) bool {
+	return !n.DeletedAt.Valid &&
+		n.EmbargoUntil != nil &&
+		!n.EmbargoUntil.After(now)
+}
+
+func (r *newsRepositoryMemory) LoadTopics(ctx context.Context, news *entities.News) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	news.Topics = r.store.topicsOf(news.Id)
+	return nil
+}
+
+func (r *newsRepositoryMemory) ReplaceTopics(ctx context.Context, news *entities.News, topics []entities.Topic) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	delete(r.store.newsTopics, news.Id)
+	for _, topic := range topics {
+		if r.store.topicById(topic.Id) != nil {
+			r.store.linkTopic(news.Id, topic.Id)
+		}
+	}
+
+	news.Topics = r.store.topicsOf(news.Id)
+	return nil
+}
+
+func (r *newsRepositoryMemory) LoadAuthors(ctx context.Context, news ...*entities.News) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, n := range news {
+		n.Authors = r.store.authorsOf(n.Id)
+	}
+	return nil
+}
+
+func (r *newsRepositoryMemory) ReplaceAuthors(ctx context.Context, news *entities.News, authors []entities.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	authorIds := []uint{}
+	for _, author := range authors {
+		authorIds = append(authorIds, author.Id)
+	}
+
+	if len(authorIds) == 0 {
+		delete(r.store.newsAuthors, news.Id)
+	} else {
+		r.store.newsAuthors[news.Id] = authorIds
+	}
+
+	news.Authors = r.store.authorsOf(news.Id)
+	return nil
+}
+
+func (r *newsRepositoryMemory) GetEmbargoedUuids(ctx context.Context, uuids []string, now time.Time) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	wanted := map[string]bool{}
+	for _, uuid := range uuids {
+		wanted[uuid] = true
+	}
+
+	embargoed := []string{}
+	for _, n := range r.store.news {
+		if wanted[n.UUID] && n.UnderEmbargo(now) {
+			embargoed = append(embargoed, n.UUID)
+		}
+	}
+
+	return embargoed, nil
+}
+
+func (r *newsRepositoryMemory) GetTrashed(ctx context.Context, pagination *common.Pagination, visibleAt *time.Time) (news []*entities.News, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.News{}
+	for _, n := range r.store.news {
+		if !n.DeletedAt.Valid {
+			continue
+		}
+		if visibleAt != nil && n.UnderEmbargo(*visibleAt) {
+			continue
+		}
+
+		matched = append(matched, n)
+	}
+
+	sortByCreatedDesc(matched, func(n *entities.News) (int64, uint) {
+		return n.DeletedAt.Time.UnixNano(), n.Id
+	})
+
+	start, end := pageBounds(len(matched), pagination)
+
+	news = []*entities.News{}
+	for _, n := range matched[start:end] {
+		news = append(news, copyNews(n))
+	}
+
+	return news, int64(len(matched)), nil
+}
+
+func (r *newsRepositoryMemory) GetTrashedByUuid(ctx context.Context, uuid string) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := r.store.findTrashedNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) RestoreByUuid(ctx context.Context, uuid string) (*entities.News, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTrashedNews(uuid)
+	if existing == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{}
+	existing.UpdatedAt = time.Now()
+
+	return copyNews(existing), nil
+}
+
+func (r *newsRepositoryMemory) PurgeByUuid(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTrashedNews(uuid)
+	if existing == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	r.store.purgeNews(existing.Id)
+	return nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsPendingEditRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewNewsPendingEditRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) NewsPendingEditRepository {
+	return &newsPendingEditRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *newsPendingEditRepositoryGorm) GetPendingEdit(ctx context.Context, newsId uint) (*entities.NewsPendingEdit, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var found *entities.NewsPendingEdit
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("news_id = ?", newsId).
+		Find(&found)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("pending edit not found")
+	}
+
+	return found, nil
+}
+
+func (r *newsPendingEditRepositoryGorm) CreatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	edit.Version = 1
+	if err := r.db.WithContext(ctx).Create(edit).Error; err != nil {
+		return nil, err
+	}
+
+	return edit, nil
+}
+
+func (r *newsPendingEditRepositoryGorm) UpdatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	expected := edit.Version
+	edit.Version = expected + 1
+
+	// selected columns are saved even when empty, so that clearing the
+	// topics is saved too
+	result := r.db.WithContext(ctx).Model(edit).
+		Where("version = ?", expected).
+		Select("title", "content", "language", "topics", "version", "updated_by", "updated_at").
+		Updates(edit)
+
+	if result.Error != nil {
+		edit.Version = expected
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		edit.Version = expected
+		return nil, common.ErrStaleVersion
+	}
+
+	return r.GetPendingEdit(common.WithPrimaryReads(ctx), edit.NewsId)
+}
+
+func (r *newsPendingEditRepositoryGorm) DeletePendingEdit(ctx context.Context, newsId uint, version int) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("news_id = ? AND version = ?", newsId, version).
+		Delete(&entities.NewsPendingEdit{})
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return common.ErrStaleVersion
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"news-topic-api/internal/entities"
+)
+
+type NewsPendingEditRepository interface {
+	GetPendingEdit(ctx context.Context, newsId uint) (*entities.NewsPendingEdit, error)
+	CreatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error)
+	// UpdatePendingEdit saves the working copy if it is still at
+	// edit.Version, bumping the version, and returns common.ErrStaleVersion
+	// otherwise.
+	UpdatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error)
+	// DeletePendingEdit removes the working copy if it is still at version,
+	// and returns common.ErrStaleVersion otherwise.
+	DeletePendingEdit(ctx context.Context, newsId uint, version int) error
+}
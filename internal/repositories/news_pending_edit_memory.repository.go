@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsPendingEditRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewNewsPendingEditRepositoryMemory(store *MemoryStore) NewsPendingEditRepository {
+	return &newsPendingEditRepositoryMemory{store}
+}
+
+func (r *newsPendingEditRepositoryMemory) GetPendingEdit(ctx context.Context, newsId uint) (*entities.NewsPendingEdit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	edit, ok := r.store.pendingEdits[newsId]
+	if !ok {
+		return nil, errors.New("pending edit not found")
+	}
+
+	return copyPendingEdit(edit), nil
+}
+
+func (r *newsPendingEditRepositoryMemory) CreatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	// the primary key on news_id
+	if _, ok := r.store.pendingEdits[edit.NewsId]; ok {
+		return nil, errors.New("pending edit already exists")
+	}
+
+	now := time.Now()
+	edit.Version = 1
+	edit.CreatedAt = now
+	edit.UpdatedAt = now
+
+	r.store.pendingEdits[edit.NewsId] = copyPendingEdit(edit)
+
+	return edit, nil
+}
+
+func (r *newsPendingEditRepositoryMemory) UpdatePendingEdit(ctx context.Context, edit *entities.NewsPendingEdit) (*entities.NewsPendingEdit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing, ok := r.store.pendingEdits[edit.NewsId]
+	if !ok || existing.Version != edit.Version {
+		return nil, common.ErrStaleVersion
+	}
+
+	updated := copyPendingEdit(edit)
+	updated.Version = existing.Version + 1
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.store.pendingEdits[edit.NewsId] = updated
+
+	return copyPendingEdit(updated), nil
+}
+
+func (r *newsPendingEditRepositoryMemory) DeletePendingEdit(ctx context.Context, newsId uint, version int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing, ok := r.store.pendingEdits[newsId]
+	if !ok || existing.Version != version {
+		return common.ErrStaleVersion
+	}
+
+	delete(r.store.pendingEdits, newsId)
+
+	return nil
+}
+
+func copyPendingEdit(edit *entities.NewsPendingEdit) *entities.NewsPendingEdit {
+	c := *edit
+	c.Topics = append([]entities.NewsRevisionTopic{}, edit.Topics...)
+	return &c
+}
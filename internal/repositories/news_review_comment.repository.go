@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsReviewCommentRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewNewsReviewCommentRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) NewsReviewCommentRepository {
+	return &newsReviewCommentRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *newsReviewCommentRepositoryGorm) CreateComment(ctx context.Context, comment *entities.NewsReviewComment) (*entities.NewsReviewComment, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *newsReviewCommentRepositoryGorm) GetComments(ctx context.Context, newsId uint) (comments []*entities.NewsReviewComment, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	err = readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("news_id = ?", newsId).
+		Order("created_at, id").
+		Find(&comments).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *newsReviewCommentRepositoryGorm) GetComment(ctx context.Context, newsId uint, uuid string) (*entities.NewsReviewComment, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var found *entities.NewsReviewComment
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("news_id = ? AND uuid = ?", newsId, uuid).
+		Find(&found)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("comment not found")
+	}
+
+	return found, nil
+}
+
+func (r *newsReviewCommentRepositoryGorm) SetResolved(ctx context.Context, comment *entities.NewsReviewComment, resolvedAt *time.Time, resolvedBy string) (*entities.NewsReviewComment, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Model(comment).
+		Updates(map[string]interface{}{
+			"resolved_at": resolvedAt,
+			"resolved_by": resolvedBy,
+		}).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment.ResolvedAt = resolvedAt
+	comment.ResolvedBy = resolvedBy
+
+	return comment, nil
+}
+
+func (r *newsReviewCommentRepositoryGorm) CountUnresolved(ctx context.Context, newsId uint) (count int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	err = readDB(ctx, r.db, r.replicas).WithContext(ctx).Model(&entities.NewsReviewComment{}).
+		Where("news_id = ? AND parent_id IS NULL AND resolved_at IS NULL", newsId).
+		Count(&count).Error
+
+	return count, err
+}
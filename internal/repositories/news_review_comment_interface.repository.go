@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/entities"
+)
+
+type NewsReviewCommentRepository interface {
+	CreateComment(ctx context.Context, comment *entities.NewsReviewComment) (*entities.NewsReviewComment, error)
+	// GetComments lists every comment on a news item, oldest first.
+	GetComments(ctx context.Context, newsId uint) ([]*entities.NewsReviewComment, error)
+	GetComment(ctx context.Context, newsId uint, uuid string) (*entities.NewsReviewComment, error)
+	// SetResolved resolves a thread, or reopens it when resolvedAt is nil.
+	SetResolved(ctx context.Context, comment *entities.NewsReviewComment, resolvedAt *time.Time, resolvedBy string) (*entities.NewsReviewComment, error)
+	// CountUnresolved counts the threads on a news item that are still open.
+	CountUnresolved(ctx context.Context, newsId uint) (int64, error)
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsReviewCommentRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewNewsReviewCommentRepositoryMemory(store *MemoryStore) NewsReviewCommentRepository {
+	return &newsReviewCommentRepositoryMemory{store}
+}
+
+func (r *newsReviewCommentRepositoryMemory) CreateComment(ctx context.Context, comment *entities.NewsReviewComment) (*entities.NewsReviewComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	r.store.lastCommentId++
+	comment.Id = r.store.lastCommentId
+	comment.UUID = uuid.NewString()
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	c := *comment
+	r.store.reviewComments = append(r.store.reviewComments, &c)
+
+	return comment, nil
+}
+
+func (r *newsReviewCommentRepositoryMemory) GetComments(ctx context.Context, newsId uint) ([]*entities.NewsReviewComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// appended in creation order, which is the order the list is served in
+	comments := []*entities.NewsReviewComment{}
+	for _, comment := range r.store.reviewComments {
+		if comment.NewsId == newsId {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+
+	return comments, nil
+}
+
+func (r *newsReviewCommentRepositoryMemory) GetComment(ctx context.Context, newsId uint, uuid string) (*entities.NewsReviewComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, comment := range r.store.reviewComments {
+		if comment.NewsId == newsId && comment.UUID == uuid {
+			c := *comment
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("comment not found")
+}
+
+func (r *newsReviewCommentRepositoryMemory) SetResolved(ctx context.Context, comment *entities.NewsReviewComment, resolvedAt *time.Time, resolvedBy string) (*entities.NewsReviewComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for _, existing := range r.store.reviewComments {
+		if existing.Id == comment.Id {
+			existing.ResolvedAt = resolvedAt
+			existing.ResolvedBy = resolvedBy
+			existing.UpdatedAt = time.Now()
+
+			c := *existing
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("comment not found")
+}
+
+func (r *newsReviewCommentRepositoryMemory) CountUnresolved(ctx context.Context, newsId uint) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, comment := range r.store.reviewComments {
+		if comment.NewsId == newsId && comment.ParentId == nil && !comment.Resolved() {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsRevisionRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewNewsRevisionRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) NewsRevisionRepository {
+	return &newsRevisionRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *newsRevisionRepositoryGorm) CreateRevision(ctx context.Context, revision *entities.NewsRevision) (*entities.NewsRevision, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	var last int
+	err := r.db.WithContext(ctx).Model(&entities.NewsRevision{}).
+		Where("news_id = ?", revision.NewsId).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&last).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the (news_id, revision) constraint rejects a concurrent writer that
+	// picked the same number
+	revision.Revision = last + 1
+
+	if err := r.db.WithContext(ctx).Create(revision).Error; err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (r *newsRevisionRepositoryGorm) GetRevisions(ctx context.Context, newsId uint, pagination *common.Pagination) (revisions []*entities.NewsRevision, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := readDB(ctx, r.db, r.replicas).WithContext(ctx).Model(&entities.NewsRevision{}).Where("news_id = ?", newsId)
+
+	err = query.Count(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("revision desc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&revisions).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, items, nil
+}
+
+func (r *newsRevisionRepositoryGorm) GetRevision(ctx context.Context, newsId uint, revision int) (*entities.NewsRevision, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var found *entities.NewsRevision
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("news_id = ? AND revision = ?", newsId, revision).
+		Find(&found)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("revision not found")
+	}
+
+	return found, nil
+}
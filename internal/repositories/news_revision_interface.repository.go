@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/entities"
+)
+
+type NewsRevisionRepository interface {
+	// CreateRevision stores the snapshot with the next revision number of
+	// its news item.
+	CreateRevision(ctx context.Context, revision *entities.NewsRevision) (*entities.NewsRevision, error)
+	GetRevisions(ctx context.Context, newsId uint, pagination *common.Pagination) (revisions []*entities.NewsRevision, items int64, err error)
+	GetRevision(ctx context.Context, newsId uint, revision int) (*entities.NewsRevision, error)
+}
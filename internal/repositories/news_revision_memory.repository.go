@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"news-topic-api/common"
+
+	"github.com/google/uuid"
+
+	"news-topic-api/internal/entities"
+)
+
+type newsRevisionRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewNewsRevisionRepositoryMemory(store *MemoryStore) NewsRevisionRepository {
+	return &newsRevisionRepositoryMemory{store}
+}
+
+func (r *newsRevisionRepositoryMemory) CreateRevision(ctx context.Context, revision *entities.NewsRevision) (*entities.NewsRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	last := 0
+	for _, rev := range r.store.revisions {
+		if rev.NewsId == revision.NewsId && rev.Revision > last {
+			last = rev.Revision
+		}
+	}
+
+	r.store.lastRevId++
+	revision.Id = r.store.lastRevId
+	revision.UUID = uuid.NewString()
+	revision.Revision = last + 1
+	revision.CreatedAt = time.Now()
+
+	c := *revision
+	r.store.revisions = append(r.store.revisions, &c)
+
+	return revision, nil
+}
+
+func (r *newsRevisionRepositoryMemory) GetRevisions(ctx context.Context, newsId uint, pagination *common.Pagination) (revisions []*entities.NewsRevision, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.NewsRevision{}
+	for _, rev := range r.store.revisions {
+		if rev.NewsId == newsId {
+			matched = append(matched, rev)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Revision > matched[j].Revision
+	})
+
+	start, end := pageBounds(len(matched), pagination)
+
+	for _, rev := range matched[start:end] {
+		c := *rev
+		revisions = append(revisions, &c)
+	}
+
+	return revisions, int64(len(matched)), nil
+}
+
+func (r *newsRevisionRepositoryMemory) GetRevision(ctx context.Context, newsId uint, revision int) (*entities.NewsRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, rev := range r.store.revisions {
+		if rev.NewsId == newsId && rev.Revision == revision {
+			c := *rev
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("revision not found")
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"gorm.io/gorm"
+)
+
+// Replicas hands out read-only connections. Replica returns nil when no
+// replica is healthy.
+type Replicas interface {
+	Replica() *gorm.DB
+}
+
+// readDB picks the connection for a list or get query. Reads stay on the
+// primary inside a unit of work, which has no replicas, when no replica is
+// healthy, and when the caller asked to read its own writes.
+func readDB(ctx context.Context, primary *gorm.DB, replicas Replicas) *gorm.DB {
+	if replicas == nil || common.PrimaryReads(ctx) {
+		return primary
+	}
+
+	if replica := replicas.Replica(); replica != nil {
+		return replica
+	}
+
+	return primary
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Repositories groups the repositories the use cases are built from, so the
+// routes can be wired against either storage backend.
+type Repositories struct {
+	News              NewsRepository
+	NewsRevision      NewsRevisionRepository
+	NewsReviewComment NewsReviewCommentRepository
+	NewsLock          NewsLockRepository
+	NewsPendingEdit   NewsPendingEditRepository
+	Author            AuthorRepository
+	Topic             TopicRepository
+	TopicPin          TopicPinRepository
+	TopicAlias        TopicAliasRepository
+	Audit             AuditRepository
+
+	UnitOfWork UnitOfWork
+}
+
+// NewRepositoriesGorm builds the repositories on the primary db. List and
+// get queries go to replicas when it is not nil.
+func NewRepositoriesGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) *Repositories {
+	return &Repositories{
+		News:              NewNewsRepositoryGorm(db, replicas, timeouts),
+		NewsRevision:      NewNewsRevisionRepositoryGorm(db, replicas, timeouts),
+		NewsReviewComment: NewNewsReviewCommentRepositoryGorm(db, replicas, timeouts),
+		NewsLock:          NewNewsLockRepositoryGorm(db, replicas, timeouts),
+		NewsPendingEdit:   NewNewsPendingEditRepositoryGorm(db, replicas, timeouts),
+		Author:            NewAuthorRepositoryGorm(db, replicas, timeouts),
+		Topic:             NewTopicRepositoryGorm(db, replicas, timeouts),
+		TopicPin:          NewTopicPinRepositoryGorm(db, replicas, timeouts),
+		TopicAlias:        NewTopicAliasRepositoryGorm(db, replicas, timeouts),
+		Audit:             NewAuditRepositoryGorm(db, replicas, timeouts),
+
+		UnitOfWork: NewUnitOfWorkGorm(db, timeouts),
+	}
+}
+
+func NewRepositoriesMemory(store *MemoryStore) *Repositories {
+	return &Repositories{
+		News:              NewNewsRepositoryMemory(store),
+		NewsRevision:      NewNewsRevisionRepositoryMemory(store),
+		NewsReviewComment: NewNewsReviewCommentRepositoryMemory(store),
+		NewsLock:          NewNewsLockRepositoryMemory(store),
+		NewsPendingEdit:   NewNewsPendingEditRepositoryMemory(store),
+		Author:            NewAuthorRepositoryMemory(store),
+		Topic:             NewTopicRepositoryMemory(store),
+		TopicPin:          NewTopicPinRepositoryMemory(store),
+		TopicAlias:        NewTopicAliasRepositoryMemory(store),
+		Audit:             NewAuditRepositoryMemory(store),
+
+		UnitOfWork: NewUnitOfWorkMemory(store),
+	}
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"news-topic-api/internal/entities"
+)
+
+// The in-memory and SQLite backends have no stemming; search falls back to
+// matching every query word case-insensitively in the title or content,
+// ranking title hits above content hits like the weighted search_vector
+// does.
+
+const snippetWords = 30
+
+func searchTerms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func searchRank(news *entities.News, terms []string) (float64, bool) {
+	if len(terms) == 0 {
+		return 0, false
+	}
+
+	title := strings.ToLower(news.Title)
+	content := strings.ToLower(news.Content)
+
+	rank := 0.0
+	for _, term := range terms {
+		inTitle := strings.Count(title, term)
+		inContent := strings.Count(content, term)
+		if inTitle+inContent == 0 {
+			return 0, false
+		}
+		rank += float64(inTitle) + 0.4*float64(inContent)
+	}
+
+	return rank / float64(len(terms)), true
+}
+
+// snippet returns the words of content around the first matching term.
+func snippet(content string, terms []string) string {
+	words := strings.Fields(content)
+	if len(words) <= snippetWords {
+		return content
+	}
+
+	start := 0
+	for i, word := range words {
+		if containsAny(strings.ToLower(word), terms) {
+			start = i - snippetWords/3
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start+snippetWords > len(words) {
+		start = len(words) - snippetWords
+	}
+
+	return strings.Join(words[start:start+snippetWords], " ")
+}
+
+func highlight(text string, terms []string) string {
+	if len(terms) == 0 {
+		return text
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+
+	re := regexp.MustCompile(`(?i)(` + strings.Join(quoted, "|") + `)`)
+	return re.ReplaceAllString(text, "<mark>$1</mark>")
+}
+
+func containsAny(s string, terms []string) bool {
+	for _, term := range terms {
+		if strings.Contains(s, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// Timeouts bounds how long a single repository call may run. Reads cover
+// list and get queries, writes cover inserts, updates and deletes. A zero
+// duration leaves the caller's deadline untouched.
+type Timeouts struct {
+	Read  time.Duration
+	Write time.Duration
+}
+
+func (t Timeouts) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Read)
+}
+
+func (t Timeouts) write(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Write)
+}
+
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"news-topic-api/common"
 
@@ -10,15 +11,46 @@ import (
 )
 
 type topicRepositoryGorm struct {
-	db *gorm.DB
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
 }
 
-func NewTopicRepositoryGorm(db *gorm.DB) TopicRepository {
-	return &topicRepositoryGorm{db}
+func NewTopicRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) TopicRepository {
+	return &topicRepositoryGorm{db, replicas, timeouts}
 }
 
-func (r *topicRepositoryGorm) GetTopics(pagination *common.Pagination) (topics []*entities.Topic, items int64, err error) {
-	err = r.db.Model(&topics).
+func (r *topicRepositoryGorm) GetTopics(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	db := readDB(ctx, r.db, r.replicas)
+
+	if pagination.Keyset {
+		query := db.WithContext(ctx)
+		if pagination.Cursor != nil {
+			query = query.Where("(created_at, id) < (?, ?)", pagination.Cursor.CreatedAt, pagination.Cursor.Id)
+		}
+
+		err = query.Order("created_at desc, id desc").
+			Limit(pagination.Limit + 1).
+			Find(&topics).
+			Error
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(topics) > pagination.Limit {
+			topics = topics[:pagination.Limit]
+			last := topics[len(topics)-1]
+			pagination.NextCursor = &common.Cursor{CreatedAt: last.CreatedAt, Id: last.Id}
+		}
+
+		return topics, 0, nil
+	}
+
+	err = db.WithContext(ctx).Model(&topics).
 		Count(&items).
 		Error
 
@@ -26,7 +58,7 @@ func (r *topicRepositoryGorm) GetTopics(pagination *common.Pagination) (topics [
 		return nil, 0, err
 	}
 
-	err = r.db.Order("created_at desc").
+	err = db.WithContext(ctx).Order("created_at desc, id desc").
 		Limit(pagination.Limit).
 		Offset(pagination.Offset).
 		Find(&topics).
@@ -39,8 +71,26 @@ func (r *topicRepositoryGorm) GetTopics(pagination *common.Pagination) (topics [
 	return topics, items, nil
 }
 
-func (r *topicRepositoryGorm) GetByUuid(uuid string) (topic *entities.Topic, err error) {
-	result := r.db.Find(&topic, "uuid = ?", uuid)
+func (r *topicRepositoryGorm) GetByUuid(ctx context.Context, uuid string) (topic *entities.Topic, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).Find(&topic, "uuid = ?", uuid)
+
+	if result.Error != nil {
+		return topic, result.Error
+	} else if result.RowsAffected == 0 {
+		return topic, errors.New("topic not found")
+	}
+
+	return topic, nil
+}
+
+func (r *topicRepositoryGorm) GetById(ctx context.Context, id uint) (topic *entities.Topic, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).Find(&topic, "id = ?", id)
 
 	if result.Error != nil {
 		return topic, result.Error
@@ -51,34 +101,284 @@ func (r *topicRepositoryGorm) GetByUuid(uuid string) (topic *entities.Topic, err
 	return topic, nil
 }
 
-func (r *topicRepositoryGorm) CreateTopic(topic *entities.Topic) (*entities.Topic, error) {
-	result := r.db.Create(topic)
+func (r *topicRepositoryGorm) GetAll(ctx context.Context) ([]*entities.Topic, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var topics []*entities.Topic
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Order("title, id").
+		Find(&topics).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+func (r *topicRepositoryGorm) GetAncestors(ctx context.Context, topics ...*entities.Topic) ([]*entities.Topic, error) {
+	ancestors := []*entities.Topic{}
+
+	parentIds := []uint{}
+	for _, topic := range topics {
+		if topic.ParentId != nil {
+			parentIds = append(parentIds, *topic.ParentId)
+		}
+	}
+	if len(parentIds) == 0 {
+		return ancestors, nil
+	}
+
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	// UNION rather than UNION ALL drops rows already seen, so a broken
+	// hierarchy cannot loop
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM topics WHERE id IN ? AND deleted_at IS NULL
+			UNION
+			SELECT topics.id, topics.parent_id FROM topics
+			JOIN ancestors ON topics.id = ancestors.parent_id
+			WHERE topics.deleted_at IS NULL
+		)
+		SELECT * FROM topics WHERE id IN (SELECT id FROM ancestors)`, parentIds).
+		Scan(&ancestors).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ancestors, nil
+}
+
+func (r *topicRepositoryGorm) GetDescendants(ctx context.Context, topicId uint) ([]*entities.Topic, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	descendants := []*entities.Topic{}
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).Raw(`
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM topics WHERE parent_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT topics.id FROM topics
+			JOIN descendants ON topics.parent_id = descendants.id
+			WHERE topics.deleted_at IS NULL
+		)
+		SELECT * FROM topics WHERE id IN (SELECT id FROM descendants) AND id <> ? ORDER BY title, id`, topicId, topicId).
+		Scan(&descendants).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return descendants, nil
+}
+
+func (r *topicRepositoryGorm) GetByValue(ctx context.Context, value string) (*entities.Topic, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var topics []*entities.Topic
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).Unscoped().
+		Where("value = ?", value).
+		Limit(1).
+		Find(&topics).Error
+
+	if err != nil || len(topics) == 0 {
+		return nil, err
+	}
+
+	return topics[0], nil
+}
+
+func (r *topicRepositoryGorm) CreateTopic(ctx context.Context, topic *entities.Topic) (*entities.Topic, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(topic)
 	if result.Error != nil {
 		return topic, result.Error
 	}
 	return topic, nil
 }
 
-func (r *topicRepositoryGorm) UpdateByUuid(uuid string, topic *entities.Topic) (*entities.Topic, error) {
-	findTopic, _ := r.GetByUuid(uuid)
+func (r *topicRepositoryGorm) UpdateByUuid(ctx context.Context, uuid string, topic *entities.Topic) (*entities.Topic, error) {
+	findTopic, err := r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	expected := topic.Version
+	topic.Version = expected + 1
 
-	result := r.db.Model(&entities.Topic{}).
-		Where("id = ?", findTopic.Id).
+	result := r.db.WithContext(ctx).Model(&entities.Topic{}).
+		Where("id = ? AND version = ?", findTopic.Id, expected).
 		Updates(topic)
 
 	if result.Error != nil {
 		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, common.ErrStaleVersion
 	}
 
 	updatedTopic := &entities.Topic{}
-	r.db.Where("id = ?", findTopic.Id).First(updatedTopic)
+	r.db.WithContext(ctx).Where("id = ?", findTopic.Id).First(updatedTopic)
 	return updatedTopic, nil
 }
 
-func (r *topicRepositoryGorm) DeleteByUuid(uuid string) error {
-	result := r.db.Delete(&entities.Topic{}, "uuid = ?", uuid)
+func (r *topicRepositoryGorm) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&entities.Topic{}, "uuid = ? AND version = ?", uuid, version)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return common.ErrStaleVersion
+	}
+	return nil
+}
+
+func (r *topicRepositoryGorm) SetParent(ctx context.Context, topicId uint, parentId *uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&entities.Topic{}).
+		Where("id = ?", topicId).
+		Update("parent_id", parentId).Error
+}
+
+func (r *topicRepositoryGorm) MoveChildren(ctx context.Context, fromTopicId, toTopicId uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&entities.Topic{}).
+		Where("parent_id = ?", fromTopicId).
+		Update("parent_id", toTopicId).Error
+}
+
+func (r *topicRepositoryGorm) CountChildren(ctx context.Context, topicId uint) (count int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	err = readDB(ctx, r.db, r.replicas).WithContext(ctx).Model(&entities.Topic{}).
+		Where("parent_id = ?", topicId).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (r *topicRepositoryGorm) GetTrashed(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	query := readDB(ctx, r.db, r.replicas).WithContext(ctx).Unscoped().Model(&entities.Topic{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("deleted_at desc, id desc").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		Find(&topics).
+		Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return topics, items, nil
+}
+
+func (r *topicRepositoryGorm) RestoreByUuid(ctx context.Context, uuid string) (*entities.Topic, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(writeCtx).Unscoped().Model(&entities.Topic{}).
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("topic not found")
+	}
+
+	return r.GetByUuid(common.WithPrimaryReads(ctx), uuid)
+}
+
+func (r *topicRepositoryGorm) PurgeByUuid(ctx context.Context, uuid string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	// news_topics, trashed_news_topics and topic_pins rows cascade
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("uuid = ? AND deleted_at IS NOT NULL", uuid).
+		Delete(&entities.Topic{})
+
 	if result.Error != nil {
 		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errors.New("topic not found")
 	}
+
 	return nil
 }
+
+func (r *topicRepositoryGorm) TrashNewsLinks(ctx context.Context, topicId uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	err := db.Exec(`INSERT INTO trashed_news_topics (topic_id, news_id)
+		SELECT topic_id, news_id FROM news_topics WHERE topic_id = ?
+		ON CONFLICT DO NOTHING`, topicId).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec("DELETE FROM news_topics WHERE topic_id = ?", topicId).Error
+}
+
+func (r *topicRepositoryGorm) RestoreNewsLinks(ctx context.Context, topicId uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	err := db.Exec(`INSERT INTO news_topics (topic_id, news_id)
+		SELECT topic_id, news_id FROM trashed_news_topics WHERE topic_id = ?
+		ON CONFLICT DO NOTHING`, topicId).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec("DELETE FROM trashed_news_topics WHERE topic_id = ?", topicId).Error
+}
+
+func (r *topicRepositoryGorm) MergeNewsLinks(ctx context.Context, fromTopicId, toTopicId uint) (int64, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+
+	result := db.Exec(`INSERT INTO news_topics (topic_id, news_id)
+		SELECT ?, news_id FROM news_topics WHERE topic_id = ?
+		ON CONFLICT DO NOTHING`, toTopicId, fromTopicId)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if err := db.Exec("DELETE FROM news_topics WHERE topic_id = ?", fromTopicId).Error; err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type topicAliasRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewTopicAliasRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) TopicAliasRepository {
+	return &topicAliasRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *topicAliasRepositoryGorm) GetAliases(ctx context.Context, topicId uint) ([]*entities.TopicAlias, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	aliases := []*entities.TopicAlias{}
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("topic_id = ?", topicId).
+		Order("value").
+		Find(&aliases).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+func (r *topicAliasRepositoryGorm) GetByUuid(ctx context.Context, uuid string) (*entities.TopicAlias, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var alias *entities.TopicAlias
+	result := readDB(ctx, r.db, r.replicas).WithContext(ctx).Find(&alias, "uuid = ?", uuid)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("topic alias not found")
+	}
+
+	return alias, nil
+}
+
+func (r *topicAliasRepositoryGorm) GetByValue(ctx context.Context, value string) (*entities.TopicAlias, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	var aliases []*entities.TopicAlias
+	err := readDB(ctx, r.db, r.replicas).WithContext(ctx).
+		Where("value = ?", value).
+		Limit(1).
+		Find(&aliases).Error
+
+	if err != nil || len(aliases) == 0 {
+		return nil, err
+	}
+
+	return aliases[0], nil
+}
+
+func (r *topicAliasRepositoryGorm) CreateAlias(ctx context.Context, alias *entities.TopicAlias) (*entities.TopicAlias, error) {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(alias).Error; err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+func (r *topicAliasRepositoryGorm) UpdateValue(ctx context.Context, id uint, value string) (*entities.TopicAlias, error) {
+	writeCtx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(writeCtx).Model(&entities.TopicAlias{}).
+		Where("id = ?", id).
+		Update("value", value)
+
+	if result.Error != nil {
+		return nil, result.Error
+	} else if result.RowsAffected == 0 {
+		return nil, errors.New("topic alias not found")
+	}
+
+	alias := &entities.TopicAlias{}
+	if err := r.db.WithContext(writeCtx).Where("id = ?", id).First(alias).Error; err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+func (r *topicAliasRepositoryGorm) DeleteByUuid(ctx context.Context, uuid string) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&entities.TopicAlias{}, "uuid = ?", uuid)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errors.New("topic alias not found")
+	}
+
+	return nil
+}
+
+func (r *topicAliasRepositoryGorm) MoveAliases(ctx context.Context, fromTopicId, toTopicId uint) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Model(&entities.TopicAlias{}).
+		Where("topic_id = ?", fromTopicId).
+		Update("topic_id", toTopicId).Error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"news-topic-api/internal/entities"
+)
+
+type TopicAliasRepository interface {
+	// GetAliases lists the aliases of a topic by value.
+	GetAliases(ctx context.Context, topicId uint) ([]*entities.TopicAlias, error)
+	GetByUuid(ctx context.Context, uuid string) (*entities.TopicAlias, error)
+	// GetByValue returns the alias with the value, or nil when there is
+	// none.
+	GetByValue(ctx context.Context, value string) (*entities.TopicAlias, error)
+	CreateAlias(ctx context.Context, alias *entities.TopicAlias) (*entities.TopicAlias, error)
+	UpdateValue(ctx context.Context, id uint, value string) (*entities.TopicAlias, error)
+	DeleteByUuid(ctx context.Context, uuid string) error
+	// MoveAliases hands every alias of one topic over to another.
+	MoveAliases(ctx context.Context, fromTopicId, toTopicId uint) error
+}
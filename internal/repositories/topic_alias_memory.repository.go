@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"news-topic-api/internal/entities"
+)
+
+type topicAliasRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewTopicAliasRepositoryMemory(store *MemoryStore) TopicAliasRepository {
+	return &topicAliasRepositoryMemory{store}
+}
+
+func (r *topicAliasRepositoryMemory) GetAliases(ctx context.Context, topicId uint) ([]*entities.TopicAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	aliases := []*entities.TopicAlias{}
+	for _, a := range r.store.topicAliases {
+		if a.TopicId == topicId {
+			c := *a
+			aliases = append(aliases, &c)
+		}
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Value < aliases[j].Value
+	})
+
+	return aliases, nil
+}
+
+func (r *topicAliasRepositoryMemory) GetByUuid(ctx context.Context, uuid string) (*entities.TopicAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, a := range r.store.topicAliases {
+		if a.UUID == uuid {
+			c := *a
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("topic alias not found")
+}
+
+func (r *topicAliasRepositoryMemory) GetByValue(ctx context.Context, value string) (*entities.TopicAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, a := range r.store.topicAliases {
+		if a.Value == value {
+			c := *a
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *topicAliasRepositoryMemory) CreateAlias(ctx context.Context, alias *entities.TopicAlias) (*entities.TopicAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if err := r.checkUnique(0, alias.Value); err != nil {
+		return nil, err
+	}
+
+	r.store.lastAliasId++
+	alias.Id = r.store.lastAliasId
+	alias.UUID = uuid.NewString()
+	alias.CreatedAt = time.Now()
+
+	c := *alias
+	r.store.topicAliases = append(r.store.topicAliases, &c)
+
+	return alias, nil
+}
+
+func (r *topicAliasRepositoryMemory) UpdateValue(ctx context.Context, id uint, value string) (*entities.TopicAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if err := r.checkUnique(id, value); err != nil {
+		return nil, err
+	}
+
+	for _, a := range r.store.topicAliases {
+		if a.Id == id {
+			a.Value = value
+			c := *a
+			return &c, nil
+		}
+	}
+
+	return nil, errors.New("topic alias not found")
+}
+
+func (r *topicAliasRepositoryMemory) DeleteByUuid(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for i, a := range r.store.topicAliases {
+		if a.UUID == uuid {
+			r.store.topicAliases = append(r.store.topicAliases[:i:i], r.store.topicAliases[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New("topic alias not found")
+}
+
+func (r *topicAliasRepositoryMemory) MoveAliases(ctx context.Context, fromTopicId, toTopicId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for _, a := range r.store.topicAliases {
+		if a.TopicId == fromTopicId {
+			a.TopicId = toTopicId
+		}
+	}
+
+	return nil
+}
+
+func (r *topicAliasRepositoryMemory) checkUnique(id uint, value string) error {
+	for _, a := range r.store.topicAliases {
+		if a.Id != id && a.Value == value {
+			return errors.New(`duplicate key value violates unique constraint "uni_topic_aliases_value"`)
+		}
+	}
+	return nil
+}
@@ -1,15 +1,60 @@
 package repositories
 
 import (
+	"context"
 	"news-topic-api/common"
 
 	"news-topic-api/internal/entities"
 )
 
 type TopicRepository interface {
-	GetTopics(pagination *common.Pagination) (topics []*entities.Topic, items int64, err error)
-	GetByUuid(uuid string) (topic *entities.Topic, err error)
-	CreateTopic(topic *entities.Topic) (*entities.Topic, error)
-	UpdateByUuid(uuid string, topic *entities.Topic) (*entities.Topic, error)
-	DeleteByUuid(uuid string) error
+	GetTopics(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error)
+	GetByUuid(ctx context.Context, uuid string) (topic *entities.Topic, err error)
+	// GetById returns the topic with the id unless it is deleted.
+	GetById(ctx context.Context, id uint) (*entities.Topic, error)
+	// GetAll lists every topic that is not deleted, by title, for building
+	// the whole tree.
+	GetAll(ctx context.Context) ([]*entities.Topic, error)
+	// GetAncestors returns the topics above the given ones, up to their
+	// roots. The walk up stops at a deleted topic.
+	GetAncestors(ctx context.Context, topics ...*entities.Topic) ([]*entities.Topic, error)
+	// GetDescendants returns the topics below a topic at any depth, by
+	// title. The walk down stops at a deleted topic.
+	GetDescendants(ctx context.Context, topicId uint) ([]*entities.Topic, error)
+	// GetByValue returns the topic with the value, deleted or not, or nil
+	// when there is none.
+	GetByValue(ctx context.Context, value string) (*entities.Topic, error)
+	CreateTopic(ctx context.Context, topic *entities.Topic) (*entities.Topic, error)
+	// UpdateByUuid writes the non-zero fields of topic as long as
+	// topic.Version is still the current version, and bumps it. Otherwise it
+	// returns common.ErrStaleVersion.
+	UpdateByUuid(ctx context.Context, uuid string, topic *entities.Topic) (*entities.Topic, error)
+	// DeleteByUuid soft deletes the topic if version is still current.
+	DeleteByUuid(ctx context.Context, uuid string, version int) error
+
+	// SetParent moves a topic under parentId, or to the root when it is
+	// nil. It does not check the version; call it after UpdateByUuid in
+	// the same unit of work.
+	SetParent(ctx context.Context, topicId uint, parentId *uint) error
+	// MoveChildren moves the live topics directly under one topic to
+	// another, without checking versions like SetParent.
+	MoveChildren(ctx context.Context, fromTopicId, toTopicId uint) error
+	// CountChildren counts the topics directly under a topic.
+	CountChildren(ctx context.Context, topicId uint) (int64, error)
+
+	// GetTrashed lists soft-deleted topics, most recently deleted first.
+	GetTrashed(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error)
+	RestoreByUuid(ctx context.Context, uuid string) (*entities.Topic, error)
+	// PurgeByUuid permanently deletes a soft-deleted topic and its links.
+	PurgeByUuid(ctx context.Context, uuid string) error
+
+	// TrashNewsLinks moves the news_topics links of a topic aside, so they
+	// survive topic updates on the news while the topic is in the trash.
+	TrashNewsLinks(ctx context.Context, topicId uint) error
+	// RestoreNewsLinks puts the links moved aside by TrashNewsLinks back.
+	RestoreNewsLinks(ctx context.Context, topicId uint) error
+	// MergeNewsLinks moves the news_topics links of one topic to another,
+	// dropping those the news already has with the target. It returns how
+	// many news gained the target topic.
+	MergeNewsLinks(ctx context.Context, fromTopicId, toTopicId uint) (int64, error)
 }
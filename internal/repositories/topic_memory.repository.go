@@ -0,0 +1,484 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"news-topic-api/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type topicRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewTopicRepositoryMemory(store *MemoryStore) TopicRepository {
+	return &topicRepositoryMemory{store}
+}
+
+func (r *topicRepositoryMemory) GetTopics(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.Topic{}
+	for _, t := range r.store.topics {
+		if !t.DeletedAt.Valid {
+			matched = append(matched, t)
+		}
+	}
+
+	sortByCreatedDesc(matched, func(t *entities.Topic) (int64, uint) {
+		return t.CreatedAt.UnixNano(), t.Id
+	})
+
+	page := matched
+	if pagination.Keyset {
+		page = keysetPage(matched, pagination, func(t *entities.Topic) (time.Time, uint) {
+			return t.CreatedAt, t.Id
+		})
+		items = 0
+	} else {
+		start, end := pageBounds(len(matched), pagination)
+		page = matched[start:end]
+		items = int64(len(matched))
+	}
+
+	for _, t := range page {
+		c := copyTopic(t)
+		topics = append(topics, &c)
+	}
+
+	return topics, items, nil
+}
+
+func (r *topicRepositoryMemory) GetByUuid(ctx context.Context, uuid string) (topic *entities.Topic, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := r.store.findTopic(uuid)
+	if existing == nil {
+		return nil, errors.New("topic not found")
+	}
+
+	c := copyTopic(existing)
+	return &c, nil
+}
+
+func (r *topicRepositoryMemory) GetById(ctx context.Context, id uint) (*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := r.store.topicById(id)
+	if existing == nil || existing.DeletedAt.Valid {
+		return nil, errors.New("topic not found")
+	}
+
+	c := copyTopic(existing)
+	return &c, nil
+}
+
+func (r *topicRepositoryMemory) GetAncestors(ctx context.Context, topics ...*entities.Topic) ([]*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ancestors := []*entities.Topic{}
+	seen := map[uint]bool{}
+	for _, topic := range topics {
+		for parentId := topic.ParentId; parentId != nil && !seen[*parentId]; {
+			parent := r.store.topicById(*parentId)
+			if parent == nil || parent.DeletedAt.Valid {
+				break
+			}
+
+			seen[parent.Id] = true
+			c := copyTopic(parent)
+			ancestors = append(ancestors, &c)
+			parentId = parent.ParentId
+		}
+	}
+
+	return ancestors, nil
+}
+
+func (r *topicRepositoryMemory) GetDescendants(ctx context.Context, topicId uint) ([]*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	descendants := []*entities.Topic{}
+	seen := map[uint]bool{topicId: true}
+	for level := []uint{topicId}; len(level) > 0; {
+		next := []uint{}
+		for _, t := range r.store.topics {
+			if t.DeletedAt.Valid || t.ParentId == nil || seen[t.Id] {
+				continue
+			}
+			for _, id := range level {
+				if *t.ParentId == id {
+					seen[t.Id] = true
+					c := copyTopic(t)
+					descendants = append(descendants, &c)
+					next = append(next, t.Id)
+					break
+				}
+			}
+		}
+		level = next
+	}
+
+	sort.Slice(descendants, func(i, j int) bool {
+		if descendants[i].Title != descendants[j].Title {
+			return descendants[i].Title < descendants[j].Title
+		}
+		return descendants[i].Id < descendants[j].Id
+	})
+
+	return descendants, nil
+}
+
+func (r *topicRepositoryMemory) GetByValue(ctx context.Context, value string) (*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, t := range r.store.topics {
+		if t.Value == value {
+			c := copyTopic(t)
+			return &c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *topicRepositoryMemory) CreateTopic(ctx context.Context, topic *entities.Topic) (*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return topic, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if err := r.checkUnique(0, topic); err != nil {
+		return topic, err
+	}
+
+	now := time.Now()
+
+	r.store.lastTopicId++
+	topic.Id = r.store.lastTopicId
+	topic.ID = r.store.lastTopicId
+	topic.UUID = uuid.NewString()
+	topic.Version = 1
+	topic.CreatedAt = now
+	topic.UpdatedAt = now
+
+	c := copyTopic(topic)
+	r.store.topics = append(r.store.topics, &c)
+
+	return topic, nil
+}
+
+func (r *topicRepositoryMemory) GetAll(ctx context.Context) ([]*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	topics := []*entities.Topic{}
+	for _, t := range r.store.topics {
+		if !t.DeletedAt.Valid {
+			c := copyTopic(t)
+			topics = append(topics, &c)
+		}
+	}
+
+	sort.SliceStable(topics, func(i, j int) bool {
+		if topics[i].Title != topics[j].Title {
+			return topics[i].Title < topics[j].Title
+		}
+		return topics[i].Id < topics[j].Id
+	})
+
+	return topics, nil
+}
+
+func (r *topicRepositoryMemory) UpdateByUuid(ctx context.Context, uuid string, topic *entities.Topic) (*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTopic(uuid)
+	if existing == nil {
+		return nil, errors.New("topic not found")
+	}
+
+	if existing.Version != topic.Version {
+		return nil, common.ErrStaleVersion
+	}
+
+	if err := r.checkUnique(existing.Id, topic); err != nil {
+		return nil, err
+	}
+
+	if topic.Title != "" {
+		existing.Title = topic.Title
+	}
+	if topic.Value != "" {
+		existing.Value = topic.Value
+	}
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+
+	updated := copyTopic(existing)
+	return &updated, nil
+}
+
+func (r *topicRepositoryMemory) SetParent(ctx context.Context, topicId uint, parentId *uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if t := r.store.topicById(topicId); t != nil {
+		t.ParentId = parentId
+	}
+	return nil
+}
+
+func (r *topicRepositoryMemory) MoveChildren(ctx context.Context, fromTopicId, toTopicId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for _, t := range r.store.topics {
+		if !t.DeletedAt.Valid && t.ParentId != nil && *t.ParentId == fromTopicId {
+			parentId := toTopicId
+			t.ParentId = &parentId
+		}
+	}
+	return nil
+}
+
+func (r *topicRepositoryMemory) CountChildren(ctx context.Context, topicId uint) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, t := range r.store.topics {
+		if !t.DeletedAt.Valid && t.ParentId != nil && *t.ParentId == topicId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *topicRepositoryMemory) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTopic(uuid)
+	if existing == nil || existing.Version != version {
+		return common.ErrStaleVersion
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (r *topicRepositoryMemory) GetTrashed(ctx context.Context, pagination *common.Pagination) (topics []*entities.Topic, items int64, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []*entities.Topic{}
+	for _, t := range r.store.topics {
+		if t.DeletedAt.Valid {
+			matched = append(matched, t)
+		}
+	}
+
+	sortByCreatedDesc(matched, func(t *entities.Topic) (int64, uint) {
+		return t.DeletedAt.Time.UnixNano(), t.Id
+	})
+
+	start, end := pageBounds(len(matched), pagination)
+
+	topics = []*entities.Topic{}
+	for _, t := range matched[start:end] {
+		c := copyTopic(t)
+		topics = append(topics, &c)
+	}
+
+	return topics, int64(len(matched)), nil
+}
+
+func (r *topicRepositoryMemory) RestoreByUuid(ctx context.Context, uuid string) (*entities.Topic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTrashedTopic(uuid)
+	if existing == nil {
+		return nil, errors.New("topic not found")
+	}
+
+	existing.DeletedAt = gorm.DeletedAt{}
+	existing.UpdatedAt = time.Now()
+
+	restored := copyTopic(existing)
+	return &restored, nil
+}
+
+func (r *topicRepositoryMemory) PurgeByUuid(ctx context.Context, uuid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	existing := r.store.findTrashedTopic(uuid)
+	if existing == nil {
+		return errors.New("topic not found")
+	}
+
+	r.store.purgeTopic(existing.Id)
+	return nil
+}
+
+func (r *topicRepositoryMemory) TrashNewsLinks(ctx context.Context, topicId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for newsId, topicIds := range r.store.newsTopics {
+		for _, id := range topicIds {
+			if id == topicId {
+				r.store.trashedNewsTopics[topicId] = append(r.store.trashedNewsTopics[topicId], newsId)
+				r.store.newsTopics[newsId] = removeId(topicIds, topicId)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *topicRepositoryMemory) RestoreNewsLinks(ctx context.Context, topicId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	for _, newsId := range r.store.trashedNewsTopics[topicId] {
+		r.store.linkTopic(newsId, topicId)
+	}
+	delete(r.store.trashedNewsTopics, topicId)
+
+	return nil
+}
+
+// checkUnique enforces the uni_topics_title and uni_topics_value
+// constraints. Like the database constraints, soft-deleted rows count.
+func (r *topicRepositoryMemory) checkUnique(id uint, topic *entities.Topic) error {
+	for _, t := range r.store.topics {
+		if t.Id == id {
+			continue
+		}
+		if topic.Title != "" && t.Title == topic.Title {
+			return errors.New(`duplicate key value violates unique constraint "uni_topics_title"`)
+		}
+		if topic.Value != "" && t.Value == topic.Value {
+			return errors.New(`duplicate key value violates unique constraint "uni_topics_value"`)
+		}
+	}
+	return nil
+}
+
+func (r *topicRepositoryMemory) MergeNewsLinks(ctx context.Context, fromTopicId, toTopicId uint) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	var moved int64
+	for newsId, topicIds := range r.store.newsTopics {
+		hasFrom, hasTo := false, false
+		for _, id := range topicIds {
+			hasFrom = hasFrom || id == fromTopicId
+			hasTo = hasTo || id == toTopicId
+		}
+		if !hasFrom {
+			continue
+		}
+
+		topicIds = removeId(topicIds, fromTopicId)
+		if !hasTo {
+			topicIds = append(topicIds, toTopicId)
+			moved++
+		}
+		r.store.newsTopics[newsId] = topicIds
+	}
+
+	return moved, nil
+}
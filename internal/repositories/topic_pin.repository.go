@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/entities"
+)
+
+type topicPinRepositoryGorm struct {
+	db       *gorm.DB
+	replicas Replicas
+	timeouts Timeouts
+}
+
+func NewTopicPinRepositoryGorm(db *gorm.DB, replicas Replicas, timeouts Timeouts) TopicPinRepository {
+	return &topicPinRepositoryGorm{db, replicas, timeouts}
+}
+
+func (r *topicPinRepositoryGorm) GetPins(ctx context.Context, topicId uint) ([]*entities.TopicPin, error) {
+	ctx, cancel := r.timeouts.read(ctx)
+	defer cancel()
+
+	db := readDB(ctx, r.db, r.replicas).WithContext(ctx)
+
+	var pins []*entities.TopicPin
+	err := db.Where("topic_id = ?", topicId).
+		Where("news_id IN (?)", db.Model(&entities.News{}).Select("id")).
+		Order("position, created_at").
+		Preload("News").
+		Find(&pins).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+func (r *topicPinRepositoryGorm) ReplacePins(ctx context.Context, topicId uint, pins []entities.TopicPin) error {
+	ctx, cancel := r.timeouts.write(ctx)
+	defer cancel()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("topic_id = ?", topicId).Delete(&entities.TopicPin{}).Error; err != nil {
+			return err
+		}
+
+		if len(pins) == 0 {
+			return nil
+		}
+
+		rows := make([]entities.TopicPin, len(pins))
+		for i, pin := range pins {
+			rows[i] = entities.TopicPin{
+				TopicId:   topicId,
+				NewsId:    pin.NewsId,
+				Position:  i + 1,
+				ExpiresAt: pin.ExpiresAt,
+				CreatedAt: pin.CreatedAt,
+			}
+		}
+
+		return tx.Omit("News").Create(&rows).Error
+	})
+}
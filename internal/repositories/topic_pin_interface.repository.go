@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"news-topic-api/internal/entities"
+)
+
+type TopicPinRepository interface {
+	// GetPins lists the pins of a topic in position order, expired ones
+	// included, with their news loaded. Pins of deleted news are left out.
+	GetPins(ctx context.Context, topicId uint) ([]*entities.TopicPin, error)
+	// ReplacePins sets the pins of a topic to pins, numbering their
+	// positions from 1 in the order given.
+	ReplacePins(ctx context.Context, topicId uint, pins []entities.TopicPin) error
+}
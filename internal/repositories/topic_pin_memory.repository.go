@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"news-topic-api/internal/entities"
+)
+
+type topicPinRepositoryMemory struct {
+	store *MemoryStore
+}
+
+func NewTopicPinRepositoryMemory(store *MemoryStore) TopicPinRepository {
+	return &topicPinRepositoryMemory{store}
+}
+
+func (r *topicPinRepositoryMemory) GetPins(ctx context.Context, topicId uint) ([]*entities.TopicPin, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pins := []*entities.TopicPin{}
+	for _, pin := range r.store.topicPins[topicId] {
+		news := r.store.newsById(pin.NewsId)
+		if news == nil {
+			continue
+		}
+
+		c := *pin
+		c.News = *copyNews(news)
+		pins = append(pins, &c)
+	}
+
+	sort.SliceStable(pins, func(i, j int) bool {
+		if pins[i].Position != pins[j].Position {
+			return pins[i].Position < pins[j].Position
+		}
+		return pins[i].CreatedAt.Before(pins[j].CreatedAt)
+	})
+
+	return pins, nil
+}
+
+func (r *topicPinRepositoryMemory) ReplacePins(ctx context.Context, topicId uint, pins []entities.TopicPin) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.lock()
+	defer r.store.unlock()
+
+	if len(pins) == 0 {
+		delete(r.store.topicPins, topicId)
+		return nil
+	}
+
+	rows := make([]*entities.TopicPin, len(pins))
+	for i, pin := range pins {
+		rows[i] = &entities.TopicPin{
+			TopicId:   topicId,
+			NewsId:    pin.NewsId,
+			Position:  i + 1,
+			ExpiresAt: pin.ExpiresAt,
+			CreatedAt: pin.CreatedAt,
+		}
+	}
+	r.store.topicPins[topicId] = rows
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWorkGorm struct {
+	db       *gorm.DB
+	timeouts Timeouts
+}
+
+func NewUnitOfWorkGorm(db *gorm.DB, timeouts Timeouts) UnitOfWork {
+	return &unitOfWorkGorm{db, timeouts}
+}
+
+// Do opens a transaction and builds the repositories on top of it, reads
+// included, so fn sees its own writes. Calling Do again from inside fn
+// nests through a savepoint.
+func (u *unitOfWorkGorm) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositoriesGorm(tx, nil, u.timeouts))
+	})
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "context"
+
+// UnitOfWork runs several repository calls as one atomic unit. The
+// repositories handed to fn are bound to the unit: if fn returns an error
+// (or panics) none of their writes are kept, otherwise all of them are.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import "context"
+
+type unitOfWorkMemory struct {
+	store *MemoryStore
+}
+
+func NewUnitOfWorkMemory(store *MemoryStore) UnitOfWork {
+	return &unitOfWorkMemory{store}
+}
+
+// Do runs fn against a private copy of the store and swaps the copy in only
+// when fn succeeds. Units of work and single writes on the store are
+// serialised, so nothing written elsewhere is lost by the swap.
+func (u *unitOfWorkMemory) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	u.store.writeMu.Lock()
+	defer u.store.writeMu.Unlock()
+
+	u.store.mu.RLock()
+	work := u.store.clone()
+	u.store.mu.RUnlock()
+
+	if err := fn(NewRepositoriesMemory(work)); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	u.store.mu.Lock()
+	u.store.replace(work)
+	u.store.mu.Unlock()
+
+	return nil
+}
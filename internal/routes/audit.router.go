@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/handlers"
+	"news-topic-api/internal/repositories"
+	"news-topic-api/internal/usecase"
+)
+
+func AuditRouter(repos *repositories.Repositories) chi.Router {
+	r := chi.NewRouter()
+	validate := validator.New()
+
+	auditUc := usecase.NewAuditUseCase(repos.Audit, repos.News, validate)
+	handler := handlers.NewAuditHandler(auditUc)
+
+	r.Get("/", handler.GetAuditEvents)
+
+	return r
+}
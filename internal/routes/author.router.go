@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/handlers"
+	"news-topic-api/internal/repositories"
+	"news-topic-api/internal/usecase"
+)
+
+func AuthorRouter(repos *repositories.Repositories) chi.Router {
+	r := chi.NewRouter()
+	validate := validator.New()
+
+	authorUc := usecase.NewAuthorUseCase(repos.Author, repos.UnitOfWork, validate)
+	newsUc := usecase.NewNewsUseCase(repos.News, repos.Topic, repos.TopicAlias, repos.NewsReviewComment, repos.UnitOfWork, validate)
+	handler := handlers.NewAuthorHandler(authorUc, newsUc)
+
+	r.Post("/", handler.CreateAuthor)
+	r.Get("/", handler.GetAuthors)
+
+	r.Route("/{uuid}", func(r chi.Router) {
+		r.Get("/", handler.GetAuthor)
+		r.Put("/", handler.UpdateAuthor)
+		r.Delete("/", handler.DeleteAuthor)
+		r.Get("/news", handler.GetAuthorNews)
+	})
+
+	return r
+}
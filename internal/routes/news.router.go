@@ -3,30 +3,92 @@ package routes
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
-	"gorm.io/gorm"
 
 	"news-topic-api/internal/delivery/handlers"
 	"news-topic-api/internal/repositories"
 	"news-topic-api/internal/usecase"
 )
 
-func NewsRouter(db *gorm.DB) chi.Router {
+func NewsRouter(repos *repositories.Repositories, opts Options) chi.Router {
 	r := chi.NewRouter()
 	validate := validator.New()
 
-	newsRepo := repositories.NewNewsRepositoryGorm(db)
-	topicRepo := repositories.NewTopicRepositoryGorm(db)
-	newsUc := usecase.NewNewsUseCase(newsRepo, topicRepo, validate)
+	newsUc := usecase.NewNewsUseCase(repos.News, repos.Topic, repos.TopicAlias, repos.NewsReviewComment, repos.UnitOfWork, validate)
 	handler := handlers.NewNewsHandler(newsUc)
 
+	revisionUc := usecase.NewNewsRevisionUseCase(repos.News, repos.NewsRevision, repos.UnitOfWork, validate)
+	revisionHandler := handlers.NewNewsRevisionHandler(revisionUc, newsUc)
+
+	scheduleUc := usecase.NewNewsScheduleUseCase(repos.News, repos.UnitOfWork, validate)
+	scheduleHandler := handlers.NewNewsScheduleHandler(scheduleUc, newsUc)
+
+	expiryUc := usecase.NewNewsExpiryUseCase(repos.News, repos.UnitOfWork, validate)
+	expiryHandler := handlers.NewNewsExpiryHandler(expiryUc, newsUc)
+
+	embargoUc := usecase.NewNewsEmbargoUseCase(repos.News, repos.UnitOfWork, validate)
+	embargoHandler := handlers.NewNewsEmbargoHandler(embargoUc, newsUc)
+
+	commentUc := usecase.NewNewsReviewCommentUseCase(repos.News, repos.NewsReviewComment, validate)
+	commentHandler := handlers.NewNewsReviewCommentHandler(commentUc)
+
+	lockUc := usecase.NewNewsLockUseCase(repos.News, repos.NewsLock, repos.UnitOfWork, opts.EditLockTTL)
+	lockHandler := handlers.NewNewsLockHandler(lockUc)
+
+	pendingUc := usecase.NewNewsPendingEditUseCase(repos.News, repos.NewsPendingEdit, repos.UnitOfWork, validate)
+	pendingHandler := handlers.NewNewsPendingEditHandler(pendingUc, newsUc)
+
+	bulkUc := usecase.NewNewsBulkUseCase(repos.News, repos.Topic, repos.TopicAlias, repos.UnitOfWork, validate)
+	bulkHandler := handlers.NewNewsBulkHandler(bulkUc)
+
 	r.Get("/", handler.GetNews)
 	r.Post("/", handler.CreateNews)
 	r.Put("/status/{uuid}", handler.UpdateNewsStatus)
+	r.Post("/bulk", bulkHandler.BulkNews)
+
+	r.Route("/trash", func(r chi.Router) {
+		r.Get("/", handler.GetTrashedNews)
+		r.Post("/{uuid}/restore", handler.RestoreNews)
+		r.Delete("/{uuid}", handler.PurgeNews)
+	})
 
 	r.Route("/{uuid}", func(r chi.Router) {
 		r.Get("/", handler.GetNewsByUuid)
 		r.Put("/", handler.UpdateNews)
 		r.Delete("/", handler.DeleteNews)
+		r.Get("/transitions", handler.GetNewsTransitions)
+
+		r.Get("/revisions", revisionHandler.GetRevisions)
+		r.Get("/revisions/diff", revisionHandler.DiffRevisions)
+		r.Get("/revisions/{revision}", revisionHandler.GetRevision)
+		r.Post("/revisions/{revision}/restore", revisionHandler.RestoreRevision)
+
+		r.Post("/schedule", scheduleHandler.ScheduleNews)
+		r.Put("/schedule", scheduleHandler.RescheduleNews)
+		r.Delete("/schedule", scheduleHandler.CancelSchedule)
+
+		r.Put("/expiry", expiryHandler.SetExpiry)
+		r.Delete("/expiry", expiryHandler.ClearExpiry)
+		r.Post("/unarchive", expiryHandler.Unarchive)
+
+		r.Put("/embargo", embargoHandler.SetEmbargo)
+		r.Delete("/embargo", embargoHandler.LiftEmbargo)
+
+		r.Get("/comments", commentHandler.GetComments)
+		r.Post("/comments", commentHandler.CreateComment)
+		r.Post("/comments/{comment}/resolve", commentHandler.ResolveComment)
+		r.Post("/comments/{comment}/reopen", commentHandler.ReopenComment)
+
+		r.Get("/lock", lockHandler.GetLock)
+		r.Post("/lock", lockHandler.Lock)
+		r.Put("/lock", lockHandler.Heartbeat)
+		r.Delete("/lock", lockHandler.Unlock)
+		r.Post("/lock/break", lockHandler.BreakLock)
+
+		r.Get("/pending", pendingHandler.GetPendingEdit)
+		r.Post("/pending", pendingHandler.StartPendingEdit)
+		r.Put("/pending", pendingHandler.UpdatePendingEdit)
+		r.Delete("/pending", pendingHandler.DiscardPendingEdit)
+		r.Post("/pending/publish", pendingHandler.PublishPendingEdit)
 	})
 
 	return r
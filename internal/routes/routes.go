@@ -2,15 +2,29 @@ package routes
 
 import (
 	"net/http"
+	"news-topic-api/common"
 	_ "news-topic-api/docs"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
-	"gorm.io/gorm"
+
+	"news-topic-api/internal/repositories"
 )
 
+const requestTimeout = 9 * time.Second
+
+// Options carries the settings the routers need beyond the repositories.
+type Options struct {
+	// InternalToken unlocks embargoed news for callers that send it.
+	InternalToken string
+	// AdminToken marks callers that send it as admins.
+	AdminToken string
+	// EditLockTTL is how long an edit lock lasts without a heartbeat.
+	EditLockTTL time.Duration
+}
+
 // @title News Topic API
 // @version 2.0
 // @description This is a sample server for managing news topics.
@@ -23,9 +37,17 @@ import (
 // @host localhost:9000
 // @BasePath /api/v1
 // @schemes http
-func InitRoutes(db *gorm.DB) *chi.Mux {
+func InitRoutes(repos *repositories.Repositories, opts Options) *chi.Mux {
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
+	// cancel the request context, and the queries running under it, before
+	// the server's WriteTimeout cuts the connection
+	r.Use(middleware.Timeout(requestTimeout))
+	r.Use(common.ReadPrimary)
+	r.Use(common.Actor)
+	r.Use(common.Internal(opts.InternalToken))
+	r.Use(common.Admin(opts.AdminToken))
 
 	r.Route("/api/v1", func(v1 chi.Router) {
 		// swagger
@@ -39,10 +61,16 @@ func InitRoutes(db *gorm.DB) *chi.Mux {
 		})
 
 		// topic
-		v1.Mount("/topics", TopicRouter(db))
+		v1.Mount("/topics", TopicRouter(repos))
 
 		// news
-		v1.Mount("/news", NewsRouter(db))
+		v1.Mount("/news", NewsRouter(repos, opts))
+
+		// author
+		v1.Mount("/authors", AuthorRouter(repos))
+
+		// audit
+		v1.Mount("/audit", AuditRouter(repos))
 	})
 
 	return r
@@ -3,28 +3,55 @@ package routes
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
-	"gorm.io/gorm"
 
 	"news-topic-api/internal/delivery/handlers"
 	"news-topic-api/internal/repositories"
 	"news-topic-api/internal/usecase"
 )
 
-func TopicRouter(db *gorm.DB) chi.Router {
+func TopicRouter(repos *repositories.Repositories) chi.Router {
 	r := chi.NewRouter()
 	validate := validator.New()
 
-	topicRepo := repositories.NewTopicRepositoryGorm(db)
-	topicUc := usecase.NewTopicUseCase(topicRepo, validate)
+	topicUc := usecase.NewTopicUseCase(repos.Topic, repos.TopicAlias, repos.UnitOfWork, validate)
 	handler := handlers.NewTopicHandler(topicUc)
 
+	pinUc := usecase.NewTopicPinUseCase(repos.Topic, repos.TopicPin, repos.UnitOfWork, validate)
+	pinHandler := handlers.NewTopicPinHandler(pinUc)
+
+	aliasUc := usecase.NewTopicAliasUseCase(repos.Topic, repos.TopicAlias, repos.UnitOfWork, validate)
+	aliasHandler := handlers.NewTopicAliasHandler(aliasUc)
+
 	r.Post("/", handler.CreateTopic)
 	r.Get("/", handler.GetTopics)
+	r.Get("/tree", handler.GetTopicTree)
+	r.Get("/lookup", handler.LookupTopic)
+
+	r.Route("/trash", func(r chi.Router) {
+		r.Get("/", handler.GetTrashedTopics)
+		r.Post("/{uuid}/restore", handler.RestoreTopic)
+		r.Delete("/{uuid}", handler.PurgeTopic)
+	})
 
 	r.Route("/{uuid}", func(r chi.Router) {
 		r.Get("/", handler.GetTopic)
 		r.Put("/", handler.UpdateTopic)
 		r.Delete("/", handler.DeleteTopic)
+		r.Post("/merge", handler.MergeTopic)
+
+		r.Route("/pins", func(r chi.Router) {
+			r.Get("/", pinHandler.GetPins)
+			r.Post("/", pinHandler.PinNews)
+			r.Put("/", pinHandler.ReorderPins)
+			r.Delete("/{news_uuid}", pinHandler.UnpinNews)
+		})
+
+		r.Route("/aliases", func(r chi.Router) {
+			r.Get("/", aliasHandler.GetAliases)
+			r.Post("/", aliasHandler.CreateAlias)
+			r.Put("/{alias_uuid}", aliasHandler.UpdateAlias)
+			r.Delete("/{alias_uuid}", aliasHandler.DeleteAlias)
+		})
 	})
 
 	return r
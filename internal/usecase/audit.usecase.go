@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+type auditUseCase struct {
+	auditRepo repositories.AuditRepository
+	newsRepo  repositories.NewsRepository
+	validate  *validator.Validate
+}
+
+func NewAuditUseCase(auditRepo repositories.AuditRepository, newsRepo repositories.NewsRepository, validate *validator.Validate) AuditUseCase {
+	return &auditUseCase{
+		auditRepo: auditRepo,
+		newsRepo:  newsRepo,
+		validate:  validate,
+	}
+}
+
+func (uc *auditUseCase) GetEvents(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterAuditRequest) (events []*response.AuditEventResponse, totalItems int, err error) {
+	if err := uc.validate.Struct(filter); err != nil {
+		return nil, 0, err
+	}
+
+	eventEntities, totalItems64, err := uc.auditRepo.GetEvents(ctx, pagination, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+
+	embargoed := map[string]bool{}
+	if !common.IsInternal(ctx) {
+		embargoed, err = uc.embargoedNews(ctx, eventEntities, now)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	events = []*response.AuditEventResponse{}
+	for _, event := range eventEntities {
+		eventResponse := &response.AuditEventResponse{
+			UUID:       event.UUID,
+			Actor:      event.Actor,
+			Action:     string(event.Action),
+			EntityType: event.EntityType,
+			EntityUuid: event.EntityUuid,
+			RequestId:  event.RequestId,
+			Before:     event.Before,
+			After:      event.After,
+			CreatedAt:  event.CreatedAt,
+		}
+
+		// the states would give away embargoed content, so public callers
+		// only learn that the event happened
+		if !common.IsInternal(ctx) && (embargoed[event.EntityUuid] || stateEmbargoed(event.Before, now) || stateEmbargoed(event.After, now)) {
+			eventResponse.Before = nil
+			eventResponse.After = nil
+			eventResponse.Redacted = true
+		}
+
+		events = append(events, eventResponse)
+	}
+
+	return events, int(totalItems64), nil
+}
+
+// embargoedNews looks up which of the news the events are about is under
+// embargo at now, so that states recorded before the embargo was set are
+// hidden as well.
+func (uc *auditUseCase) embargoedNews(ctx context.Context, events []*entities.AuditEvent, now time.Time) (map[string]bool, error) {
+	uuids := []string{}
+	for _, event := range events {
+		if event.EntityType == entities.AuditEntityNews {
+			uuids = append(uuids, event.EntityUuid)
+		}
+	}
+
+	embargoedUuids, err := uc.newsRepo.GetEmbargoedUuids(ctx, uuids, now)
+	if err != nil {
+		return nil, err
+	}
+
+	embargoed := map[string]bool{}
+	for _, uuid := range embargoedUuids {
+		embargoed[uuid] = true
+	}
+
+	return embargoed, nil
+}
+
+// embargoState picks the embargo out of a recorded news state or a pin
+// holding one.
+type embargoState struct {
+	EmbargoUntil *time.Time `json:"embargo_until"`
+	News         *struct {
+		EmbargoUntil *time.Time `json:"embargo_until"`
+	} `json:"news"`
+}
+
+func (state embargoState) embargoedAt(now time.Time) bool {
+	if state.EmbargoUntil != nil && state.EmbargoUntil.After(now) {
+		return true
+	}
+
+	return state.News != nil && state.News.EmbargoUntil != nil && state.News.EmbargoUntil.After(now)
+}
+
+// stateEmbargoed reports whether a recorded state, a news item or a list of
+// pins, holds news whose embargo has not ended at now. This covers news
+// that has since been purged and pinned news recorded on a topic.
+func stateEmbargoed(state json.RawMessage, now time.Time) bool {
+	state = bytes.TrimSpace(state)
+	if len(state) == 0 {
+		return false
+	}
+
+	if state[0] == '[' {
+		var states []embargoState
+		if err := json.Unmarshal(state, &states); err != nil {
+			return false
+		}
+		for _, s := range states {
+			if s.embargoedAt(now) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var s embargoState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return false
+	}
+
+	return s.embargoedAt(now)
+}
+
+// recordAudit writes an audit event inside the unit of work making the
+// change, so an event exists exactly when its change was committed. before
+// and after are stored as JSON, nil meaning the entity did not exist.
+func recordAudit(ctx context.Context, repos *repositories.Repositories, action entities.AuditAction, entityType string, entityUuid string, before, after any) error {
+	beforeJSON, err := auditState(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := auditState(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = repos.Audit.CreateEvent(ctx, &entities.AuditEvent{
+		Actor:      common.ActorFrom(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityUuid: entityUuid,
+		RequestId:  common.RequestIdFrom(ctx),
+		Before:     beforeJSON,
+		After:      afterJSON,
+	})
+
+	return err
+}
+
+func auditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(state)
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type AuditUseCase interface {
+	GetEvents(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterAuditRequest) (events []*response.AuditEventResponse, totalItems int, err error)
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var ErrAuthorSlugTaken = errors.New("another author already has this slug")
+
+// ErrInvalidAuthorSlug is returned for a slug that is not lowercase letters
+// and digits joined by hyphens, or a name no slug can be made from.
+var ErrInvalidAuthorSlug = errors.New("slug must be lowercase letters and digits joined by hyphens")
+
+var ErrDuplicateAuthor = errors.New("an author can only appear once in a byline")
+
+type authorUseCase struct {
+	authorRepo repositories.AuthorRepository
+	uow        repositories.UnitOfWork
+	validate   *validator.Validate
+}
+
+func NewAuthorUseCase(authorRepo repositories.AuthorRepository, uow repositories.UnitOfWork, validate *validator.Validate) AuthorUseCase {
+	return &authorUseCase{
+		authorRepo: authorRepo,
+		uow:        uow,
+		validate:   validate,
+	}
+}
+
+func (uc *authorUseCase) GetAllAuthors(ctx context.Context, pagination *common.Pagination) (authors []*response.AuthorResponse, totalItems int, err error) {
+	authorEntities, totalItems64, err := uc.authorRepo.GetAuthors(ctx, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	authors = []*response.AuthorResponse{}
+	for _, author := range authorEntities {
+		authors = append(authors, toAuthorResponse(author))
+	}
+
+	return authors, int(totalItems64), nil
+}
+
+func (uc *authorUseCase) GetByUuid(ctx context.Context, uuid string) (*response.AuthorResponse, error) {
+	author, err := uc.authorRepo.GetByUuid(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuthorResponse(author), nil
+}
+
+func (uc *authorUseCase) CreateAuthor(ctx context.Context, authorDto dtos.CreateAuthorRequest) (*response.AuthorResponse, error) {
+	if err := uc.validate.Struct(&authorDto); err != nil {
+		return nil, err
+	}
+
+	slug := authorDto.Slug
+	if slug == "" {
+		slug = common.Slugify(authorDto.Name)
+	}
+	if !common.IsSlug(slug) {
+		return nil, ErrInvalidAuthorSlug
+	}
+
+	var created *entities.Author
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		if err := checkAuthorSlug(ctx, repos, slug, ""); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Author.CreateAuthor(ctx, &entities.Author{
+			Name:      authorDto.Name,
+			Slug:      slug,
+			Bio:       authorDto.Bio,
+			AvatarURL: authorDto.AvatarURL,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionCreate, entities.AuditEntityAuthor, created.UUID, nil, toAuthorResponse(created))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuthorResponse(created), nil
+}
+
+func (uc *authorUseCase) UpdateByUuid(ctx context.Context, uuid string, version int, authorDto dtos.UpdateAuthorRequest) (*response.AuthorResponse, error) {
+	if err := uc.validate.Struct(&authorDto); err != nil {
+		return nil, err
+	}
+
+	if authorDto.Slug != "" && !common.IsSlug(authorDto.Slug) {
+		return nil, ErrInvalidAuthorSlug
+	}
+
+	var updated *entities.Author
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existing, err := repos.Author.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if existing.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if authorDto.Slug != "" {
+			if err := checkAuthorSlug(ctx, repos, authorDto.Slug, uuid); err != nil {
+				return err
+			}
+		}
+
+		updated, err = repos.Author.UpdateByUuid(ctx, uuid, &entities.Author{
+			Name:      authorDto.Name,
+			Slug:      authorDto.Slug,
+			Bio:       authorDto.Bio,
+			AvatarURL: authorDto.AvatarURL,
+			Version:   version,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityAuthor, uuid, toAuthorResponse(existing), toAuthorResponse(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuthorResponse(updated), nil
+}
+
+func (uc *authorUseCase) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		author, err := repos.Author.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if author.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if err := repos.Author.DeleteByUuid(ctx, uuid, version); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionDelete, entities.AuditEntityAuthor, uuid, toAuthorResponse(author), nil)
+	})
+}
+
+// checkAuthorSlug fails with ErrAuthorSlugTaken when an author other than
+// the one with ownUuid has slug.
+func checkAuthorSlug(ctx context.Context, repos *repositories.Repositories, slug string, ownUuid string) error {
+	author, err := repos.Author.GetBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	if author != nil && author.UUID != ownUuid {
+		return ErrAuthorSlugTaken
+	}
+
+	return nil
+}
+
+func toAuthorResponse(author *entities.Author) *response.AuthorResponse {
+	return &response.AuthorResponse{
+		Id:        author.Id,
+		UUID:      author.UUID,
+		Name:      author.Name,
+		Slug:      author.Slug,
+		Bio:       author.Bio,
+		AvatarURL: author.AvatarURL,
+		Version:   author.Version,
+	}
+}
+
+// authorResponses is a byline as news responses show it.
+func authorResponses(authors []entities.Author) []response.AuthorResponse {
+	authorResponses := make([]response.AuthorResponse, len(authors))
+	for i, author := range authors {
+		authorResponses[i] = *toAuthorResponse(&author)
+	}
+
+	return authorResponses
+}
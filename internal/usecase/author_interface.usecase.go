@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type AuthorUseCase interface {
+	GetAllAuthors(ctx context.Context, pagination *common.Pagination) (authors []*response.AuthorResponse, totalItems int, err error)
+	GetByUuid(ctx context.Context, uuid string) (*response.AuthorResponse, error)
+	CreateAuthor(ctx context.Context, authorDto dtos.CreateAuthorRequest) (*response.AuthorResponse, error)
+	// The writes below take the version the client last read and fail with
+	// common.ErrStaleVersion when the author was changed since.
+	UpdateByUuid(ctx context.Context, uuid string, version int, authorDto dtos.UpdateAuthorRequest) (*response.AuthorResponse, error)
+	// DeleteByUuid removes the author from every byline.
+	DeleteByUuid(ctx context.Context, uuid string, version int) error
+}
@@ -1,8 +1,11 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"news-topic-api/common"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -13,273 +16,614 @@ import (
 )
 
 type newsUseCase struct {
-	newsRepo  repositories.NewsRepository
-	topicRepo repositories.TopicRepository
-	validate  *validator.Validate
+	newsRepo    repositories.NewsRepository
+	topicRepo   repositories.TopicRepository
+	aliasRepo   repositories.TopicAliasRepository
+	commentRepo repositories.NewsReviewCommentRepository
+	uow         repositories.UnitOfWork
+	validate    *validator.Validate
 }
 
-func NewNewsUseCase(newsRepo repositories.NewsRepository, topicRepo repositories.TopicRepository, validate *validator.Validate) NewsUseCase {
+func NewNewsUseCase(newsRepo repositories.NewsRepository, topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, commentRepo repositories.NewsReviewCommentRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsUseCase {
 	return &newsUseCase{
-		newsRepo:  newsRepo,
-		topicRepo: topicRepo,
-		validate:  validate,
+		newsRepo:    newsRepo,
+		topicRepo:   topicRepo,
+		aliasRepo:   aliasRepo,
+		commentRepo: commentRepo,
+		uow:         uow,
+		validate:    validate,
 	}
 }
 
-func (uc *newsUseCase) GetAllNews(pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*response.NewsResponse, totalItems int, err error) {
-	newsEntities, totalItems64, err := uc.newsRepo.GetNews(pagination, filter)
+func (uc *newsUseCase) GetAllNews(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*response.NewsResponse, totalItems int, err error) {
+	if err := uc.validate.Struct(filter); err != nil {
+		return nil, 0, err
+	}
+
+	if pagination.Keyset {
+		if pagination.Limit < 1 {
+			return nil, 0, fmt.Errorf("%w: per_page must be at least 1", common.ErrInvalidPagination)
+		}
+		// search results are ordered by rank, which a created_at cursor cannot follow
+		if filter.Query != nil {
+			return nil, 0, fmt.Errorf("%w: cursor cannot be combined with q", common.ErrInvalidPagination)
+		}
+	}
+
+	if !common.IsInternal(ctx) {
+		now := time.Now()
+		filter.VisibleAt = &now
+	}
+
+	// pins order a topic's offset pages; cursors follow created_at and
+	// search results their rank
+	if filter.Topic != nil && !pagination.Keyset && filter.Query == nil {
+		now := time.Now()
+		filter.PinnedAt = &now
+	}
+
+	if err := expandTopicFilter(ctx, uc.topicRepo, uc.aliasRepo, filter); err != nil {
+		return nil, 0, err
+	}
+
+	newsEntities, totalItems64, err := uc.newsRepo.GetNews(ctx, pagination, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := uc.newsRepo.LoadAuthors(ctx, newsEntities...); err != nil {
+		return nil, 0, err
+	}
+
 	newsResponses := []*response.NewsResponse{}
 	for _, newsEntity := range newsEntities {
-		if err := uc.newsRepo.LoadTopics(newsEntity); err != nil {
+		if err := uc.newsRepo.LoadTopics(ctx, newsEntity); err != nil {
 			return nil, 0, err
 		}
 
-		topics := newsEntity.Topics
-		topicResponses := make([]response.TopicResponse, len(topics))
-		for i, topic := range topics {
-			topicResponses[i] = response.TopicResponse{
-				Id:    topic.Id,
-				UUID:  topic.UUID,
-				Title: topic.Title,
-				Value: topic.Value,
+		newsResponse := toNewsResponse(newsEntity)
+		newsResponse.PinPosition = newsEntity.PinPosition
+
+		if filter.Query != nil {
+			score := newsEntity.SearchRank
+			newsResponse.Score = &score
+			newsResponse.Highlights = &response.NewsHighlights{
+				Title:   newsEntity.SearchTitle,
+				Content: newsEntity.SearchSnippet,
 			}
 		}
 
-		newsResponses = append(newsResponses, &response.NewsResponse{
-			Id:      newsEntity.Id,
-			UUID:    newsEntity.UUID,
-			Title:   newsEntity.Title,
-			Content: newsEntity.Content,
-			Status:  string(newsEntity.Status),
-			Topics:  topicResponses,
-		})
+		newsResponses = append(newsResponses, newsResponse)
 	}
 
 	return newsResponses, int(totalItems64), nil
 }
 
-func (uc *newsUseCase) GetByUuid(uuid string) (*response.NewsResponse, error) {
-	newsEntity, err := uc.newsRepo.GetByUuid(uuid)
+func (uc *newsUseCase) GetByUuid(ctx context.Context, uuid string) (*response.NewsResponse, error) {
+	newsEntity, err := getVisibleNews(ctx, uc.newsRepo, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.newsRepo.LoadTopics(newsEntity); err != nil {
+	if err := uc.newsRepo.LoadTopics(ctx, newsEntity); err != nil {
 		return nil, err
 	}
 
-	topicResponses := make([]response.TopicResponse, len(newsEntity.Topics))
-	for i, topic := range newsEntity.Topics {
-		topicResponses[i] = response.TopicResponse{
-			Id:    topic.Id,
-			UUID:  topic.UUID,
-			Title: topic.Title,
-			Value: topic.Value,
-		}
-	}
-
-	newsResponse := &response.NewsResponse{
-		Id:      newsEntity.Id,
-		UUID:    newsEntity.UUID,
-		Title:   newsEntity.Title,
-		Content: newsEntity.Content,
-		Status:  string(newsEntity.Status),
-		Topics:  topicResponses,
+	if err := uc.newsRepo.LoadAuthors(ctx, newsEntity); err != nil {
+		return nil, err
 	}
 
-	return newsResponse, nil
+	return toNewsResponse(newsEntity), nil
 }
 
-func (uc *newsUseCase) CreateNews(newsDto dtos.CreateNewsRequest) (*response.NewsResponse, error) {
+func (uc *newsUseCase) CreateNews(ctx context.Context, newsDto dtos.CreateNewsRequest) (*response.NewsResponse, error) {
 	if err := uc.validate.Struct(&newsDto); err != nil {
 		return nil, err
 	}
 
-	tx, err := uc.newsRepo.BeginTransaction()
+	if newsDto.EmbargoUntil != nil {
+		if !common.IsInternal(ctx) {
+			return nil, ErrEmbargoInternalOnly
+		}
+		if !newsDto.EmbargoUntil.After(time.Now()) {
+			return nil, ErrEmbargoNotInFuture
+		}
+		// UTC for the same reason as futurePublishAt
+		embargoUntil := newsDto.EmbargoUntil.UTC()
+		newsDto.EmbargoUntil = &embargoUntil
+	}
+
+	draft := &entities.News{
+		Title:        newsDto.Title,
+		Content:      newsDto.Content,
+		Status:       entities.StatusType(newsDto.Status),
+		Language:     entities.LanguageType(newsDto.Language),
+		EmbargoUntil: newsDto.EmbargoUntil,
+	}
+
+	if err := draft.CheckInitialStatus(entities.TransitionContext{Now: time.Now()}); err != nil {
+		return nil, err
+	}
+
+	var newsEntity *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		var topicEntities []entities.Topic
+		for _, topicDto := range newsDto.Topics {
+			topicEntity, err := repos.Topic.GetByUuid(ctx, topicDto.Uuid)
+			if err != nil {
+				return err
+			}
+			if topicEntity == nil {
+				return errors.New("topic entity not found")
+			}
+
+			topicEntities = append(topicEntities, *topicEntity)
+		}
+
+		draft.Topics = topicEntities
+
+		authorEntities, err := bylineAuthors(ctx, repos, newsDto.Authors)
+		if err != nil {
+			return err
+		}
+
+		created, err := repos.News.CreateNews(ctx, draft)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.News.ReplaceAuthors(ctx, created, authorEntities); err != nil {
+			return err
+		}
+
+		newsEntity = created
+		if err := recordRevision(ctx, repos, created); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionCreate, entities.AuditEntityNews, created.UUID, nil, toNewsResponse(created))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			uc.newsRepo.RollbackTransaction(tx)
-		} else if err != nil {
-			uc.newsRepo.RollbackTransaction(tx)
+	return toNewsResponse(newsEntity), nil
+}
+
+func (uc *newsUseCase) UpdateByUuid(ctx context.Context, uuid string, version int, newsDto dtos.UpdateNewsRequest) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&newsDto); err != nil {
+		return nil, err
+	}
+
+	var updatedNews *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existingNews, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, existingNews, time.Now()); err != nil {
+			return err
+		}
+
+		if existingNews.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if err := checkEditLock(ctx, repos, existingNews, time.Now()); err != nil {
+			return err
+		}
+
+		if existingNews.Status == entities.NewsStatusPublished {
+			return errors.New("published news cannot be edited directly, edit its pending copy instead")
+		}
+
+		if existingNews.Status != entities.NewsStatusDraft {
+			return fmt.Errorf("only draft news can be edited, this news is %s", existingNews.Status)
+		}
+
+		if err := repos.News.LoadTopics(ctx, existingNews); err != nil {
+			return err
+		}
+		if err := repos.News.LoadAuthors(ctx, existingNews); err != nil {
+			return err
+		}
+		before := toNewsResponse(existingNews)
+
+		if newsDto.Title != "" {
+			existingNews.Title = newsDto.Title
+		}
+		if newsDto.Content != "" {
+			existingNews.Content = newsDto.Content
+		}
+		if newsDto.Language != "" {
+			existingNews.Language = entities.LanguageType(newsDto.Language)
+		}
+
+		// the guards see the edited content, so a draft can be fixed and
+		// submitted in one request
+		if newsDto.Status != "" {
+			tc, err := transitionContext(ctx, repos.NewsReviewComment, existingNews, time.Now())
+			if err != nil {
+				return err
+			}
+
+			status := entities.StatusType(newsDto.Status)
+			if err := checkStatusChange(existingNews, status, tc); err != nil {
+				return err
+			}
+			existingNews.Status = status
+		}
+
+		updatedNews, err = repos.News.UpdateByUuid(ctx, uuid, existingNews)
+		if err != nil {
+			return err
+		}
+
+		if len(newsDto.Topics) > 0 {
+			topicEntities := []entities.Topic{}
+			for _, topicDto := range newsDto.Topics {
+				topicEntity, err := repos.Topic.GetByUuid(ctx, topicDto.Uuid)
+				if err != nil {
+					return err
+				}
+				if topicEntity == nil {
+					return errors.New("topic entity not found")
+				}
+				topicEntities = append(topicEntities, *topicEntity)
+			}
+
+			if err := repos.News.ReplaceTopics(ctx, updatedNews, topicEntities); err != nil {
+				return err
+			}
+		}
+
+		if len(newsDto.Authors) > 0 {
+			authorEntities, err := bylineAuthors(ctx, repos, newsDto.Authors)
+			if err != nil {
+				return err
+			}
+
+			if err := repos.News.ReplaceAuthors(ctx, updatedNews, authorEntities); err != nil {
+				return err
+			}
 		} else {
-			err = uc.newsRepo.CommitTransaction(tx)
+			updatedNews.Authors = existingNews.Authors
+		}
+
+		if err := recordRevision(ctx, repos, updatedNews); err != nil {
+			return err
 		}
-	}()
 
-	var status entities.StatusType
-	switch newsDto.Status {
-	case "published":
-		status = entities.NewsStatusPublished
-	case "draft":
-		status = entities.NewsStatusDraft
-	default:
-		return nil, errors.New("invalid status")
+		return recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityNews, uuid, before, toNewsResponse(updatedNews))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	var topicEntities []entities.Topic
-	var topicResponses []response.TopicResponse
-	for _, topicDto := range newsDto.Topics {
-		topicEntity, err := uc.topicRepo.GetByUuid(topicDto.Uuid)
+	return toNewsResponse(updatedNews), nil
+}
+
+// DeleteByUuid marks the news as deleted and soft deletes it in one unit of
+// work, so a failed delete never leaves a "deleted" row behind.
+func (uc *newsUseCase) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		newsExisting, err := repos.News.GetByUuid(ctx, uuid)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if topicEntity == nil {
-			return nil, errors.New("topic entity not found")
+
+		if err := checkEmbargo(ctx, newsExisting, time.Now()); err != nil {
+			return err
 		}
 
-		topicEntities = append(topicEntities, *topicEntity)
-		topicResponses = append(topicResponses, response.TopicResponse{
-			Id:    topicEntity.Id,
-			UUID:  topicEntity.UUID,
-			Title: topicEntity.Title,
-			Value: topicEntity.Value,
-		})
+		if newsExisting.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		return deleteNews(ctx, repos, newsExisting)
+	})
+}
+
+// deleteNews marks news as deleted and soft deletes it inside a unit of
+// work, recording a revision and an audit event.
+func deleteNews(ctx context.Context, repos *repositories.Repositories, news *entities.News) error {
+	if err := news.CheckTransition(entities.NewsStatusDeleted, entities.TransitionContext{Now: time.Now()}); err != nil {
+		return err
 	}
 
-	newsEntity := &entities.News{
-		Title:   newsDto.Title,
-		Content: newsDto.Content,
-		Status:  status,
-		Topics:  topicEntities,
+	if err := repos.News.LoadTopics(ctx, news); err != nil {
+		return err
 	}
+	before := toNewsResponse(news)
 
-	newsEntity, err = uc.newsRepo.CreateNews(newsEntity)
+	updateStatusDto := dtos.UpdateNewsStatus{
+		Status: string(entities.NewsStatusDeleted),
+	}
+	deletedNews, err := repos.News.UpdateNewsStatus(ctx, news.UUID, news.Version, updateStatusDto)
 	if err != nil {
+		return err
+	}
+
+	if err := recordRevision(ctx, repos, deletedNews); err != nil {
+		return err
+	}
+
+	if err := repos.News.DeleteByUuid(ctx, news.UUID); err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, repos, entities.AuditActionDelete, entities.AuditEntityNews, news.UUID, before, nil)
+}
+
+func (uc *newsUseCase) UpdateNewsStatus(ctx context.Context, uuid string, version int, dto dtos.UpdateNewsStatus) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
 		return nil, err
 	}
 
-	newsResponse := &response.NewsResponse{
-		Id:      newsEntity.ID,
-		UUID:    newsEntity.UUID,
-		Title:   newsEntity.Title,
-		Content: newsEntity.Content,
-		Status:  string(newsEntity.Status),
-		Topics:  topicResponses,
+	var updatedNews *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existingNews, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, existingNews, time.Now()); err != nil {
+			return err
+		}
+
+		if existingNews.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		updatedNews, err = changeNewsStatus(ctx, repos, existingNews, entities.StatusType(dto.Status))
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return newsResponse, nil
+	return toNewsResponse(updatedNews), nil
 }
 
-func (uc *newsUseCase) UpdateByUuid(uuid string, newsDto dtos.UpdateNewsRequest) (*response.NewsResponse, error) {
-	if err := uc.validate.Struct(&newsDto); err != nil {
+func (uc *newsUseCase) GetTransitions(ctx context.Context, uuid string) (*response.NewsTransitionsResponse, error) {
+	newsEntity, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
 		return nil, err
 	}
 
-	existingNews, err := uc.newsRepo.GetByUuid(uuid)
+	tc, err := transitionContext(ctx, uc.commentRepo, newsEntity, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	if existingNews.Status != entities.NewsStatusDraft {
-		return nil, errors.New("news is not in draft status")
+	transitions := []response.NewsTransitionResponse{}
+	for _, transition := range newsEntity.Transitions(tc) {
+		transitions = append(transitions, response.NewsTransitionResponse{
+			To:      string(transition.To),
+			Allowed: transition.Allowed,
+			Reason:  transition.Reason,
+		})
 	}
 
-	if newsDto.Title != "" {
-		existingNews.Title = newsDto.Title
+	transitionsResponse := &response.NewsTransitionsResponse{
+		Status:      string(newsEntity.Status),
+		Transitions: transitions,
 	}
-	if newsDto.Content != "" {
-		existingNews.Content = newsDto.Content
+
+	return transitionsResponse, nil
+}
+
+func (uc *newsUseCase) GetTrashedNews(ctx context.Context, pagination *common.Pagination) (news []*response.NewsResponse, totalItems int, err error) {
+	var visibleAt *time.Time
+	if !common.IsInternal(ctx) {
+		now := time.Now()
+		visibleAt = &now
 	}
 
-	if newsDto.Status != "" {
-		var status entities.StatusType
-		switch newsDto.Status {
-		case "published":
-			status = entities.NewsStatusPublished
-		default:
-			return nil, errors.New("invalid status")
-		}
-		existingNews.Status = status
+	newsEntities, totalItems64, err := uc.newsRepo.GetTrashed(ctx, pagination, visibleAt)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if len(newsDto.Topics) > 0 {
-		topicEntities := []entities.Topic{}
-		for _, topicDto := range newsDto.Topics {
-			topicEntity, err := uc.topicRepo.GetByUuid(topicDto.Uuid)
-			if err != nil {
-				return nil, err
-			}
-			if topicEntity == nil {
-				return nil, errors.New("topic entity not found")
-			}
-			topicEntities = append(topicEntities, *topicEntity)
+	newsResponses := []*response.NewsResponse{}
+	for _, newsEntity := range newsEntities {
+		if err := uc.newsRepo.LoadTopics(ctx, newsEntity); err != nil {
+			return nil, 0, err
 		}
-		existingNews.Topics = topicEntities
+		if err := uc.newsRepo.LoadAuthors(ctx, newsEntity); err != nil {
+			return nil, 0, err
+		}
+
+		deletedAt := newsEntity.DeletedAt.Time
+		newsResponse := toNewsResponse(newsEntity)
+		newsResponse.DeletedAt = &deletedAt
+		newsResponses = append(newsResponses, newsResponse)
 	}
 
-	updatedNews, err := uc.newsRepo.UpdateByUuid(uuid, existingNews)
+	return newsResponses, int(totalItems64), nil
+}
+
+// RestoreByUuid takes a news item out of the trash. It comes back as a draft
+// so it is reviewed before being published again.
+func (uc *newsUseCase) RestoreByUuid(ctx context.Context, uuid string) (*response.NewsResponse, error) {
+	var restoredNews *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		if err := checkTrashedEmbargo(ctx, repos, uuid); err != nil {
+			return err
+		}
+
+		trashedNews, err := repos.News.RestoreByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := trashedNews.CheckTransition(entities.NewsStatusDraft, entities.TransitionContext{Now: time.Now()}); err != nil {
+			return err
+		}
+
+		if err := repos.News.LoadTopics(ctx, trashedNews); err != nil {
+			return err
+		}
+		before := toNewsResponse(trashedNews)
+
+		updateStatusDto := dtos.UpdateNewsStatus{
+			Status: string(entities.NewsStatusDraft),
+		}
+
+		restoredNews, err = repos.News.UpdateNewsStatus(ctx, uuid, trashedNews.Version, updateStatusDto)
+		if err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, repos, restoredNews); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionRestore, entities.AuditEntityNews, uuid, before, toNewsResponse(restoredNews))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	topicResponses := make([]response.TopicResponse, len(updatedNews.Topics))
-	for i, topic := range updatedNews.Topics {
-		topicResponses[i] = response.TopicResponse{
-			Id:    topic.Id,
-			UUID:  topic.UUID,
-			Title: topic.Title,
-			Value: topic.Value,
+	return toNewsResponse(restoredNews), nil
+}
+
+// PurgeByUuid deletes a trashed news item for good. Its audit event keeps
+// no copy of the content, since purging is how content is removed.
+func (uc *newsUseCase) PurgeByUuid(ctx context.Context, uuid string) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		if err := checkTrashedEmbargo(ctx, repos, uuid); err != nil {
+			return err
 		}
-	}
 
-	newsResponse := &response.NewsResponse{
-		Id:      updatedNews.Id,
-		UUID:    updatedNews.UUID,
-		Title:   updatedNews.Title,
-		Content: updatedNews.Content,
-		Status:  string(updatedNews.Status),
-		Topics:  topicResponses,
-	}
+		if err := repos.News.PurgeByUuid(ctx, uuid); err != nil {
+			return err
+		}
 
-	return newsResponse, nil
+		return recordAudit(ctx, repos, entities.AuditActionPurge, entities.AuditEntityNews, uuid, nil, nil)
+	})
 }
 
-func (uc *newsUseCase) DeleteByUuid(uuid string) error {
-	newsExisting, err := uc.newsRepo.GetByUuid(uuid)
+// checkTrashedEmbargo applies checkEmbargo to news in the trash.
+func checkTrashedEmbargo(ctx context.Context, repos *repositories.Repositories, uuid string) error {
+	trashedNews, err := repos.News.GetTrashedByUuid(common.WithPrimaryReads(ctx), uuid)
 	if err != nil {
 		return err
 	}
 
-	if newsExisting.Status == entities.NewsStatusDeleted && newsExisting.DeletedAt.Valid {
-		return errors.New("news already deleted")
+	return checkEmbargo(ctx, trashedNews, time.Now())
+}
+
+// changeNewsStatus moves news to status inside a unit of work after the
+// state machine allowed it, recording a revision and an audit event.
+func changeNewsStatus(ctx context.Context, repos *repositories.Repositories, news *entities.News, status entities.StatusType) (*entities.News, error) {
+	tc, err := transitionContext(ctx, repos.NewsReviewComment, news, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
-	updateStatusDto := dtos.UpdateNewsStatus{
-		Status: string(entities.NewsStatusDeleted),
+	if err := checkStatusChange(news, status, tc); err != nil {
+		return nil, err
 	}
-	if _, err := uc.UpdateNewsStatus(uuid, updateStatusDto); err != nil {
-		return err
+
+	if err := repos.News.LoadTopics(ctx, news); err != nil {
+		return nil, err
 	}
+	before := toNewsResponse(news)
 
-	return uc.newsRepo.DeleteByUuid(uuid)
+	updatedNews, err := repos.News.UpdateNewsStatus(ctx, news.UUID, news.Version, dtos.UpdateNewsStatus{Status: string(status)})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordRevision(ctx, repos, updatedNews); err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, repos, entities.AuditActionStatusChange, entities.AuditEntityNews, news.UUID, before, toNewsResponse(updatedNews)); err != nil {
+		return nil, err
+	}
+
+	return updatedNews, nil
 }
 
-func (uc *newsUseCase) UpdateNewsStatus(uuid string, dto dtos.UpdateNewsStatus) (*response.NewsResponse, error) {
-	status := entities.StatusType(dto.Status)
-	if status != entities.NewsStatusPublished && status != entities.NewsStatusDeleted {
-		return nil, errors.New("invalid status")
+// checkStatusChange runs a status change asked for through the update and
+// status endpoints past the state machine. Scheduling and deleting need
+// their own endpoints, which do more than set the status.
+func checkStatusChange(news *entities.News, status entities.StatusType, tc entities.TransitionContext) error {
+	switch status {
+	case entities.NewsStatusScheduled:
+		return &entities.TransitionError{From: news.Status, To: status, Reason: "schedule through POST /news/{uuid}/schedule"}
+	case entities.NewsStatusDeleted:
+		return &entities.TransitionError{From: news.Status, To: status, Reason: "delete through DELETE /news/{uuid}"}
 	}
 
-	updatedNews, err := uc.newsRepo.UpdateNewsStatus(uuid, dto)
+	return news.CheckTransition(status, tc)
+}
+
+// transitionContext gathers what the guards check besides the news item
+// itself.
+func transitionContext(ctx context.Context, comments repositories.NewsReviewCommentRepository, news *entities.News, now time.Time) (entities.TransitionContext, error) {
+	unresolved, err := comments.CountUnresolved(ctx, news.Id)
 	if err != nil {
-		return nil, err
+		return entities.TransitionContext{}, err
 	}
 
-	newsResponse := &response.NewsResponse{
-		Id:      updatedNews.Id,
-		UUID:    updatedNews.UUID,
-		Title:   updatedNews.Title,
-		Content: updatedNews.Content,
-		Status:  string(updatedNews.Status),
+	return entities.TransitionContext{Now: now, UnresolvedComments: int(unresolved)}, nil
+}
+
+// toNewsResponse is the news item as GET /news/{uuid} returns it, topics
+// and authors included when they are loaded.
+func toNewsResponse(news *entities.News) *response.NewsResponse {
+	topicResponses := make([]response.TopicResponse, len(news.Topics))
+	for i, topic := range news.Topics {
+		topicResponses[i] = response.TopicResponse{
+			Id:      topic.Id,
+			UUID:    topic.UUID,
+			Title:   topic.Title,
+			Value:   topic.Value,
+			Version: topic.Version,
+		}
+	}
+
+	return &response.NewsResponse{
+		Id:           news.Id,
+		UUID:         news.UUID,
+		Title:        news.Title,
+		Content:      news.Content,
+		Status:       string(news.Status),
+		Language:     string(news.Language),
+		Version:      news.Version,
+		PublishAt:    news.PublishAt,
+		ExpiresAt:    news.ExpiresAt,
+		EmbargoUntil: news.EmbargoUntil,
+		Embargoed:    news.UnderEmbargo(time.Now()),
+		Topics:       topicResponses,
+		Authors:      authorResponses(news.Authors),
+	}
+}
+
+// bylineAuthors looks up the authors of a byline in the order given.
+func bylineAuthors(ctx context.Context, repos *repositories.Repositories, authorDtos []dtos.AuthorUuid) ([]entities.Author, error) {
+	seen := map[string]bool{}
+	authors := []entities.Author{}
+	for _, authorDto := range authorDtos {
+		if seen[authorDto.Uuid] {
+			return nil, ErrDuplicateAuthor
+		}
+		seen[authorDto.Uuid] = true
+
+		author, err := repos.Author.GetByUuid(ctx, authorDto.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, *author)
 	}
 
-	return newsResponse, nil
+	return authors, nil
 }
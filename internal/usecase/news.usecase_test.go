@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingUnitOfWork runs the real unit of work but lets a test swap some of
+// the repositories it hands out, to make a step fail halfway through.
+type failingUnitOfWork struct {
+	repositories.UnitOfWork
+	wrap func(repos *repositories.Repositories)
+}
+
+func (u *failingUnitOfWork) Do(ctx context.Context, fn func(repos *repositories.Repositories) error) error {
+	return u.UnitOfWork.Do(ctx, func(repos *repositories.Repositories) error {
+		wrapped := *repos
+		u.wrap(&wrapped)
+		return fn(&wrapped)
+	})
+}
+
+// failingNews writes through to the real repository and then fails, as if
+// the next statement of the same write had. With recordOnly set it just
+// records the inserts, for a failure further down the write.
+type failingNews struct {
+	repositories.NewsRepository
+	inserted   []*entities.News
+	recordOnly bool
+}
+
+func (r *failingNews) CreateNews(ctx context.Context, news *entities.News) (*entities.News, error) {
+	created, err := r.NewsRepository.CreateNews(ctx, news)
+	if err != nil {
+		return nil, err
+	}
+	r.inserted = append(r.inserted, created)
+	if r.recordOnly {
+		return created, nil
+	}
+	return nil, errInjected
+}
+
+func (r *failingNews) DeleteByUuid(ctx context.Context, uuid string) error {
+	if err := r.NewsRepository.DeleteByUuid(ctx, uuid); err != nil {
+		return err
+	}
+	return errInjected
+}
+
+// failingRevisions fails every snapshot, the last step of a news write.
+type failingRevisions struct {
+	repositories.NewsRevisionRepository
+}
+
+func (r *failingRevisions) CreateRevision(ctx context.Context, revision *entities.NewsRevision) (*entities.NewsRevision, error) {
+	return nil, errInjected
+}
+
+// failingAudit fails the audit event written last in every news write.
+type failingAudit struct {
+	repositories.AuditRepository
+}
+
+func (failingAudit) CreateEvent(ctx context.Context, event *entities.AuditEvent) (*entities.AuditEvent, error) {
+	return nil, errInjected
+}
+
+func (b backend) failingNewsUseCase(wrap func(repos *repositories.Repositories)) NewsUseCase {
+	uow := &failingUnitOfWork{UnitOfWork: b.repos.UnitOfWork, wrap: wrap}
+	return NewNewsUseCase(b.repos.News, b.repos.Topic, b.repos.TopicAlias, b.repos.NewsReviewComment, uow, validator.New())
+}
+
+func revisionCount(t *testing.T, b backend, newsId uint) int64 {
+	t.Helper()
+
+	_, items, err := b.repos.NewsRevision.GetRevisions(testContext(), newsId, firstPage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestCreateNewsLeavesNothingBehindOnFailure(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+
+		failing := &failingNews{}
+		cases := map[string]func(repos *repositories.Repositories){
+			"missing topic": func(repos *repositories.Repositories) {
+				failing.NewsRepository = repos.News
+			},
+			"failure after insert": func(repos *repositories.Repositories) {
+				failing.NewsRepository = repos.News
+				failing.recordOnly = false
+				repos.News = failing
+			},
+			"revision after insert": func(repos *repositories.Repositories) {
+				failing.NewsRepository = repos.News
+				failing.recordOnly = true
+				repos.News = failing
+				repos.NewsRevision = &failingRevisions{repos.NewsRevision}
+			},
+			"audit after insert": func(repos *repositories.Repositories) {
+				failing.NewsRepository = repos.News
+				failing.recordOnly = true
+				repos.News = failing
+				repos.Audit = failingAudit{repos.Audit}
+			},
+		}
+
+		// a unit of work that only rolls back the memory store would leave
+		// rows here on SQLite
+		tables := []string{"news", "news_topics", "news_revisions", "audit_events"}
+		rowsBefore := map[string]int64{}
+		for _, table := range tables {
+			rowsBefore[table] = b.countRows(t, table)
+		}
+
+		for name, wrap := range cases {
+			t.Run(name, func(t *testing.T) {
+				failing.inserted = nil
+
+				dto := dtos.CreateNewsRequest{
+					Title:   "Final tonight",
+					Content: "content",
+					Status:  "draft",
+					Topics:  []dtos.TopicUuid{{Uuid: sport.UUID}},
+				}
+				if name == "missing topic" {
+					dto.Topics = append(dto.Topics, dtos.TopicUuid{Uuid: "00000000-0000-0000-0000-000000000000"})
+				}
+
+				_, err := b.failingNewsUseCase(wrap).CreateNews(ctx, dto)
+				if name == "missing topic" && !isTopicNotFound(err) {
+					t.Fatalf("CreateNews with a missing topic: got %v", err)
+				} else if name != "missing topic" && !errors.Is(err, errInjected) {
+					t.Fatalf("CreateNews: got %v", err)
+				}
+				if name != "missing topic" && len(failing.inserted) == 0 {
+					t.Fatal("the failure came before the news was inserted")
+				}
+
+				for _, news := range failing.inserted {
+					if _, err := b.repos.News.GetByUuid(ctx, news.UUID); err == nil {
+						t.Errorf("news %s was kept", news.UUID)
+					}
+					if count := revisionCount(t, b, news.ID); count != 0 {
+						t.Errorf("%d revisions of news %s were kept", count, news.UUID)
+					}
+				}
+
+				value := "sport"
+				_, total, err := b.newsUseCase().GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if total != 0 {
+					t.Errorf("%d news listed under sport, want none", total)
+				}
+
+				for _, table := range tables {
+					if count := b.countRows(t, table); count != rowsBefore[table] {
+						t.Errorf("%d rows in %s, want %d", count, table, rowsBefore[table])
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestUpdateNewsLeavesNothingBehindOnFailure(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+		created := createNews(t, b.newsUseCase(), "Final tonight", sport)
+		b.lockNews(t, created.UUID)
+
+		// the topics are looked up after the news row is updated
+		dto := dtos.UpdateNewsRequest{
+			Title:  "Final postponed",
+			Topics: []dtos.TopicUuid{{Uuid: "00000000-0000-0000-0000-000000000000"}},
+		}
+		if _, err := b.newsUseCase().UpdateByUuid(ctx, created.UUID, created.Version, dto); !isTopicNotFound(err) {
+			t.Fatalf("UpdateByUuid with a missing topic: got %v", err)
+		}
+
+		dto.Topics = nil
+		withFailingRevision := b.failingNewsUseCase(func(repos *repositories.Repositories) {
+			repos.NewsRevision = &failingRevisions{repos.NewsRevision}
+		})
+		if _, err := withFailingRevision.UpdateByUuid(ctx, created.UUID, created.Version, dto); !errors.Is(err, errInjected) {
+			t.Fatalf("UpdateByUuid with a failing revision: got %v", err)
+		}
+
+		assertNewsUnchanged(t, b, created)
+	})
+}
+
+func TestDeleteNewsLeavesNothingBehindOnFailure(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+		created := createNews(t, b.newsUseCase(), "Final tonight", sport)
+
+		// the status change and the soft delete both happen before the
+		// failure
+		withFailingDelete := b.failingNewsUseCase(func(repos *repositories.Repositories) {
+			repos.News = &failingNews{NewsRepository: repos.News}
+		})
+		if err := withFailingDelete.DeleteByUuid(ctx, created.UUID, created.Version); !errors.Is(err, errInjected) {
+			t.Fatalf("DeleteByUuid with a failing delete: got %v", err)
+		}
+
+		// the audit event is written after the status change and the soft
+		// delete
+		withFailingAudit := b.failingNewsUseCase(func(repos *repositories.Repositories) {
+			repos.Audit = failingAudit{repos.Audit}
+		})
+		if err := withFailingAudit.DeleteByUuid(ctx, created.UUID, created.Version); !errors.Is(err, errInjected) {
+			t.Fatalf("DeleteByUuid with a failing audit: got %v", err)
+		}
+
+		assertNewsUnchanged(t, b, created)
+
+		_, total, err := b.newsUseCase().GetTrashedNews(ctx, firstPage())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 0 {
+			t.Errorf("%d news in the trash, want none", total)
+		}
+	})
+}
+
+// assertNewsUnchanged checks the news still reads as it did when it was
+// created, with its single creation revision.
+func assertNewsUnchanged(t *testing.T, b backend, want *response.NewsResponse) {
+	t.Helper()
+
+	got, err := b.newsUseCase().GetByUuid(testContext(), want.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != want.Title || got.Status != want.Status || got.Version != want.Version {
+		t.Errorf("news is now %q, %s at version %d, want %q, %s at version %d", got.Title, got.Status, got.Version, want.Title, want.Status, want.Version)
+	}
+	if len(got.Topics) != len(want.Topics) || got.Topics[0].UUID != want.Topics[0].UUID {
+		t.Errorf("topics are now %+v, want %+v", got.Topics, want.Topics)
+	}
+
+	if count := revisionCount(t, b, want.Id); count != 1 {
+		t.Errorf("%d revisions, want the creation one only", count)
+	}
+	if count := b.countRows(t, "news_topics"); count >= 0 && count != int64(len(want.Topics)) {
+		t.Errorf("%d news_topics rows, want %d", count, len(want.Topics))
+	}
+}
+
+func TestGetAllNewsCursorPaging(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+
+		var created []string
+		for _, title := range []string{"one", "two", "three", "four", "five"} {
+			created = append(created, createNews(t, news, title).UUID)
+		}
+
+		// on SQLite every item gets the same creation time, so the order
+		// and the cursor rest on the id alone
+		if b.db != nil {
+			if err := b.db.Exec("UPDATE news SET created_at = ?", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// newest first, and every item exactly once
+		var seen []string
+		pagination := &common.Pagination{Limit: 2, Keyset: true}
+		for pages := 0; ; pages++ {
+			if pages > len(created) {
+				t.Fatal("paging does not end")
+			}
+
+			page, _, err := news.GetAllNews(ctx, pagination, &dtos.FilterNewsRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range page {
+				seen = append(seen, item.UUID)
+			}
+
+			if pagination.NextCursor == nil {
+				break
+			}
+			pagination = &common.Pagination{Limit: 2, Keyset: true, Cursor: pagination.NextCursor}
+		}
+
+		if len(seen) != len(created) {
+			t.Fatalf("paged through %d items, want %d", len(seen), len(created))
+		}
+		for i, uuid := range seen {
+			if want := created[len(created)-1-i]; uuid != want {
+				t.Errorf("item %d is %s, want %s", i, uuid, want)
+			}
+		}
+
+		if _, _, err := news.GetAllNews(ctx, &common.Pagination{Keyset: true}, &dtos.FilterNewsRequest{}); !errors.Is(err, common.ErrInvalidPagination) {
+			t.Errorf("cursor paging without a page size: got %v", err)
+		}
+	})
+}
+
+func TestNewsWritesAnswerLikeGet(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		created := createNews(t, news, "Final tonight", createTopic(t, b.topicUseCase(), "Sport", "sport"))
+
+		writes := []func(current *response.NewsResponse) (*response.NewsResponse, error){
+			func(current *response.NewsResponse) (*response.NewsResponse, error) {
+				return current, nil
+			},
+			func(current *response.NewsResponse) (*response.NewsResponse, error) {
+				return news.UpdateNewsStatus(ctx, current.UUID, current.Version, dtos.UpdateNewsStatus{Status: "in_review"})
+			},
+			func(current *response.NewsResponse) (*response.NewsResponse, error) {
+				if err := news.DeleteByUuid(ctx, current.UUID, current.Version); err != nil {
+					return nil, err
+				}
+				return news.RestoreByUuid(ctx, current.UUID)
+			},
+		}
+
+		current := created
+		for i, write := range writes {
+			var err error
+			current, err = write(current)
+			if err != nil {
+				t.Fatalf("write %d: %v", i, err)
+			}
+
+			got, err := news.GetByUuid(ctx, created.UUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current.Id != got.Id || current.Status != got.Status || current.Version != got.Version || len(current.Topics) != 1 || current.Topics[0].UUID != got.Topics[0].UUID {
+				t.Errorf("write %d answered %+v, GET answers %+v", i, current, got)
+			}
+		}
+	})
+}
+
+// countingReplicas hands out the primary as its one replica and counts how
+// often a repository asked for one.
+type countingReplicas struct {
+	db    *gorm.DB
+	picks int
+}
+
+func (r *countingReplicas) Replica() *gorm.DB {
+	r.picks++
+	return r.db
+}
+
+func TestGetNewsPicksOneReplica(t *testing.T) {
+	conn := newSQLiteDB(t)
+	replicas := &countingReplicas{db: conn}
+	b := backend{repos: repositories.NewRepositoriesGorm(conn, replicas, repositories.Timeouts{}), db: conn}
+	created := createNews(t, b.newsUseCase(), "Final tonight", createTopic(t, b.topicUseCase(), "Sport", "sport"))
+
+	// the topic subquery runs on the same replica as the query around it
+	replicas.picks = 0
+	value := "sport"
+	listed, _, err := b.repos.News.GetNews(testContext(), firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].UUID != created.UUID {
+		t.Errorf("got %d items, want only %s", len(listed), created.UUID)
+	}
+	if replicas.picks != 1 {
+		t.Errorf("listing picked a replica %d times, want once", replicas.picks)
+	}
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// maxBulkNews caps the news items one bulk request may touch, so a loose
+// filter cannot hold a transaction open over the whole table.
+const maxBulkNews = 500
+
+var ErrBulkTooLarge = fmt.Errorf("a bulk action takes at most %d news", maxBulkNews)
+
+const (
+	bulkModeTransaction = "transaction"
+	bulkModePerItem     = "per_item"
+)
+
+const (
+	bulkResultSucceeded  = "succeeded"
+	bulkResultUnchanged  = "unchanged"
+	bulkResultFailed     = "failed"
+	bulkResultRolledBack = "rolled_back"
+	bulkResultSkipped    = "skipped"
+)
+
+// bulkAction changes one news item inside a unit of work and returns the
+// item's result and, when it is still there, its new version.
+type bulkAction func(ctx context.Context, repos *repositories.Repositories, news *entities.News) (result string, version int, err error)
+
+type newsBulkUseCase struct {
+	newsRepo  repositories.NewsRepository
+	topicRepo repositories.TopicRepository
+	aliasRepo repositories.TopicAliasRepository
+	uow       repositories.UnitOfWork
+	validate  *validator.Validate
+}
+
+func NewNewsBulkUseCase(newsRepo repositories.NewsRepository, topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsBulkUseCase {
+	return &newsBulkUseCase{
+		newsRepo:  newsRepo,
+		topicRepo: topicRepo,
+		aliasRepo: aliasRepo,
+		uow:       uow,
+		validate:  validate,
+	}
+}
+
+func (uc *newsBulkUseCase) Bulk(ctx context.Context, dto dtos.BulkNewsRequest) (*response.BulkNewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	if dto.Mode == "" {
+		dto.Mode = bulkModeTransaction
+	}
+
+	action, err := uc.action(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	uuids, err := uc.targets(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]response.BulkNewsResultResponse, len(uuids))
+	for i, uuid := range uuids {
+		results[i].UUID = uuid
+	}
+
+	if dto.Mode == bulkModePerItem {
+		for i, uuid := range uuids {
+			err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+				return applyBulk(ctx, repos, uuid, action, &results[i])
+			})
+			if err != nil {
+				results[i] = response.BulkNewsResultResponse{UUID: uuid, Result: bulkResultFailed, Error: err.Error()}
+			}
+		}
+	} else {
+		failed := -1
+		err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+			for i, uuid := range uuids {
+				if err := applyBulk(ctx, repos, uuid, action, &results[i]); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && failed < 0 {
+			return nil, err
+		}
+
+		if err != nil {
+			for i := range results {
+				switch {
+				case i < failed:
+					results[i] = response.BulkNewsResultResponse{UUID: results[i].UUID, Result: bulkResultRolledBack}
+				case i == failed:
+					results[i] = response.BulkNewsResultResponse{UUID: results[i].UUID, Result: bulkResultFailed, Error: err.Error()}
+				default:
+					results[i].Result = bulkResultSkipped
+				}
+			}
+		}
+	}
+
+	bulkResponse := &response.BulkNewsResponse{
+		Action:  dto.Action,
+		Mode:    dto.Mode,
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Result == bulkResultSucceeded || result.Result == bulkResultUnchanged {
+			bulkResponse.Succeeded++
+		} else {
+			bulkResponse.Failed++
+		}
+	}
+
+	return bulkResponse, nil
+}
+
+// targets lists the UUIDs the request names, in order and without
+// duplicates, or those of the news its filter matches.
+func (uc *newsBulkUseCase) targets(ctx context.Context, dto dtos.BulkNewsRequest) ([]string, error) {
+	if dto.Filter == nil {
+		uuids := []string{}
+		seen := map[string]bool{}
+		for _, uuid := range dto.UUIDs {
+			if !seen[uuid] {
+				seen[uuid] = true
+				uuids = append(uuids, uuid)
+			}
+		}
+
+		if len(uuids) > maxBulkNews {
+			return nil, ErrBulkTooLarge
+		}
+		return uuids, nil
+	}
+
+	// the same news GET /news would list
+	filter := *dto.Filter
+	if !common.IsInternal(ctx) {
+		now := time.Now()
+		filter.VisibleAt = &now
+	}
+	if err := expandTopicFilter(ctx, uc.topicRepo, uc.aliasRepo, &filter); err != nil {
+		return nil, err
+	}
+
+	pagination := &common.Pagination{Limit: maxBulkNews, Offset: 0, Page: 1}
+
+	newsEntities, items, err := uc.newsRepo.GetNews(common.WithPrimaryReads(ctx), pagination, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if items > maxBulkNews {
+		return nil, ErrBulkTooLarge
+	}
+
+	uuids := make([]string, len(newsEntities))
+	for i, news := range newsEntities {
+		uuids[i] = news.UUID
+	}
+
+	return uuids, nil
+}
+
+// action resolves the request's topics up front, so a missing topic fails
+// the whole request instead of every item.
+func (uc *newsBulkUseCase) action(ctx context.Context, dto dtos.BulkNewsRequest) (bulkAction, error) {
+	topics := []entities.Topic{}
+	for _, topicDto := range dto.Topics {
+		topic, err := uc.topicRepo.GetByUuid(ctx, topicDto.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, *topic)
+	}
+
+	switch dto.Action {
+	case "publish":
+		return bulkStatus(entities.NewsStatusPublished), nil
+	case "archive":
+		return bulkStatus(entities.NewsStatusArchived), nil
+	case "delete":
+		return func(ctx context.Context, repos *repositories.Repositories, news *entities.News) (string, int, error) {
+			return bulkResultSucceeded, 0, deleteNews(ctx, repos, news)
+		}, nil
+	case "add_topics":
+		return bulkTopics(topics, true), nil
+	default:
+		return bulkTopics(topics, false), nil
+	}
+}
+
+// applyBulk runs action on one news item and fills in its result.
+func applyBulk(ctx context.Context, repos *repositories.Repositories, uuid string, action bulkAction, result *response.BulkNewsResultResponse) error {
+	news, err := repos.News.GetByUuid(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+		return err
+	}
+
+	result.Result, result.Version, err = action(ctx, repos, news)
+	return err
+}
+
+func bulkStatus(status entities.StatusType) bulkAction {
+	return func(ctx context.Context, repos *repositories.Repositories, news *entities.News) (string, int, error) {
+		if news.Status == status {
+			return bulkResultUnchanged, 0, nil
+		}
+
+		updated, err := changeNewsStatus(ctx, repos, news, status)
+		if err != nil {
+			return "", 0, err
+		}
+
+		return bulkResultSucceeded, updated.Version, nil
+	}
+}
+
+// bulkTopics adds or removes topics on news in any status. Topics are not
+// part of what editorial review approves, but someone else's edit lock
+// still applies.
+func bulkTopics(topics []entities.Topic, add bool) bulkAction {
+	return func(ctx context.Context, repos *repositories.Repositories, news *entities.News) (string, int, error) {
+		if err := checkForeignLock(ctx, repos, news, time.Now()); err != nil {
+			return "", 0, err
+		}
+
+		if err := repos.News.LoadTopics(ctx, news); err != nil {
+			return "", 0, err
+		}
+		before := toNewsResponse(news)
+
+		listed := map[uint]bool{}
+		for _, topic := range topics {
+			listed[topic.Id] = true
+		}
+
+		kept := []entities.Topic{}
+		has := map[uint]bool{}
+		for _, topic := range news.Topics {
+			has[topic.Id] = true
+			if add || !listed[topic.Id] {
+				kept = append(kept, topic)
+			}
+		}
+		if add {
+			for _, topic := range topics {
+				if !has[topic.Id] {
+					kept = append(kept, topic)
+					has[topic.Id] = true
+				}
+			}
+		}
+
+		if len(kept) == len(news.Topics) {
+			return bulkResultUnchanged, 0, nil
+		}
+
+		updated, err := repos.News.UpdateByUuid(ctx, news.UUID, news)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if err := repos.News.ReplaceTopics(ctx, updated, kept); err != nil {
+			return "", 0, err
+		}
+
+		if err := recordRevision(ctx, repos, updated); err != nil {
+			return "", 0, err
+		}
+
+		if err := recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityNews, news.UUID, before, toNewsResponse(updated)); err != nil {
+			return "", 0, err
+		}
+
+		return bulkResultSucceeded, updated.Version, nil
+	}
+}
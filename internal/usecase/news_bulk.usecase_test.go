@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+const missingNewsUuid = "00000000-0000-0000-0000-000000000000"
+
+func (b backend) bulkUseCase() NewsBulkUseCase {
+	return NewNewsBulkUseCase(b.repos.News, b.repos.Topic, b.repos.TopicAlias, b.repos.UnitOfWork, validator.New())
+}
+
+func bulkResults(res *response.BulkNewsResponse) []string {
+	results := []string{}
+	for _, result := range res.Results {
+		results = append(results, result.Result)
+	}
+	return results
+}
+
+func TestBulkTransactionRollsBackEveryItem(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+		first := createNews(t, news, "Final tonight")
+		last := createNews(t, news, "Elections")
+
+		// the first item is tagged before the missing one fails
+		res, err := b.bulkUseCase().Bulk(ctx, dtos.BulkNewsRequest{
+			Action: "add_topics",
+			UUIDs:  []string{first.UUID, missingNewsUuid, last.UUID},
+			Topics: []dtos.TopicUuid{{Uuid: sport.UUID}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{bulkResultRolledBack, bulkResultFailed, bulkResultSkipped}
+		if got := bulkResults(res); len(got) != 3 || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+			t.Errorf("results are %v, want %v", got, want)
+		}
+		if res.Mode != bulkModeTransaction || res.Succeeded != 0 || res.Failed != 3 {
+			t.Errorf("%s: %d succeeded and %d failed, want transaction with all 3 failed", res.Mode, res.Succeeded, res.Failed)
+		}
+		if res.Results[1].Error == "" {
+			t.Error("the failed item carries no error")
+		}
+
+		for _, created := range []*response.NewsResponse{first, last} {
+			got, err := news.GetByUuid(ctx, created.UUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Topics) != 0 || got.Version != created.Version {
+				t.Errorf("%s has topics %+v at version %d, want none at version %d", created.Title, got.Topics, got.Version, created.Version)
+			}
+		}
+	})
+}
+
+func TestBulkPerItemReportsEachItem(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+		untagged := createNews(t, news, "Final tonight")
+		tagged := createNews(t, news, "Match report", sport)
+
+		res, err := b.bulkUseCase().Bulk(ctx, dtos.BulkNewsRequest{
+			Action: "add_topics",
+			UUIDs:  []string{untagged.UUID, missingNewsUuid, tagged.UUID},
+			Topics: []dtos.TopicUuid{{Uuid: sport.UUID}},
+			Mode:   bulkModePerItem,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{bulkResultSucceeded, bulkResultFailed, bulkResultUnchanged}
+		if got := bulkResults(res); len(got) != 3 || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+			t.Errorf("results are %v, want %v", got, want)
+		}
+		if res.Succeeded != 2 || res.Failed != 1 {
+			t.Errorf("%d succeeded and %d failed, want 2 and 1", res.Succeeded, res.Failed)
+		}
+		if res.Results[0].Version != untagged.Version+1 || res.Results[1].Error == "" {
+			t.Errorf("results are %+v, want the new version of the first item and an error for the second", res.Results)
+		}
+
+		got, err := news.GetByUuid(ctx, untagged.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Topics) != 1 || got.Topics[0].UUID != sport.UUID || got.Version != untagged.Version+1 {
+			t.Errorf("first item has topics %+v at version %d, want sport at version %d", got.Topics, got.Version, untagged.Version+1)
+		}
+	})
+}
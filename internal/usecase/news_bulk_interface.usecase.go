@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsBulkUseCase interface {
+	// Bulk runs the action on every news item the request names, under the
+	// same rules as the single-item endpoints but without version checks.
+	// Failing items are reported in the response rather than as an error.
+	Bulk(ctx context.Context, dto dtos.BulkNewsRequest) (*response.BulkNewsResponse, error)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var ErrEmbargoNotInFuture = errors.New("embargo_until must be in the future")
+
+// ErrNewsNotEmbargoed is returned when lifting an embargo the news does not
+// have.
+var ErrNewsNotEmbargoed = errors.New("news is not under embargo")
+
+// ErrEmbargoInternalOnly is returned when a public caller tries to set or
+// lift an embargo.
+var ErrEmbargoInternalOnly = errors.New("only internal callers can embargo news")
+
+// checkEmbargo reports embargoed news as not found to public callers, so
+// that reads and writes alike cannot tell it exists.
+func checkEmbargo(ctx context.Context, news *entities.News, now time.Time) error {
+	if news.UnderEmbargo(now) && !common.IsInternal(ctx) {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// getVisibleNews loads news by uuid and applies checkEmbargo to it.
+func getVisibleNews(ctx context.Context, newsRepo repositories.NewsRepository, uuid string) (*entities.News, error) {
+	news, err := newsRepo.GetByUuid(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+type newsEmbargoUseCase struct {
+	newsRepo repositories.NewsRepository
+	uow      repositories.UnitOfWork
+	validate *validator.Validate
+}
+
+func NewNewsEmbargoUseCase(newsRepo repositories.NewsRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsEmbargoUseCase {
+	return &newsEmbargoUseCase{
+		newsRepo: newsRepo,
+		uow:      uow,
+		validate: validate,
+	}
+}
+
+func (uc *newsEmbargoUseCase) SetEmbargo(ctx context.Context, uuid string, version int, dto dtos.EmbargoNewsRequest) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	if !dto.EmbargoUntil.After(time.Now()) {
+		return nil, ErrEmbargoNotInFuture
+	}
+
+	// UTC for the same reason as futurePublishAt
+	embargoUntil := dto.EmbargoUntil.UTC()
+
+	return uc.changeEmbargo(ctx, uuid, version, &embargoUntil, entities.AuditActionUpdate)
+}
+
+func (uc *newsEmbargoUseCase) LiftEmbargo(ctx context.Context, uuid string, version int) (*response.NewsResponse, error) {
+	return uc.changeEmbargo(ctx, uuid, version, nil, entities.AuditActionEmbargoLift)
+}
+
+// changeEmbargo sets or, with a nil embargoUntil, lifts the embargo,
+// recording an audit event. The embargo is not part of the content, so no
+// revision is taken.
+func (uc *newsEmbargoUseCase) changeEmbargo(ctx context.Context, uuid string, version int, embargoUntil *time.Time, action entities.AuditAction) (*response.NewsResponse, error) {
+	if !common.IsInternal(ctx) {
+		return nil, ErrEmbargoInternalOnly
+	}
+
+	var changed *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existing, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if existing.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if embargoUntil == nil && !existing.UnderEmbargo(time.Now()) {
+			return ErrNewsNotEmbargoed
+		}
+
+		if err := repos.News.LoadTopics(ctx, existing); err != nil {
+			return err
+		}
+		before := toNewsResponse(existing)
+
+		changed, err = repos.News.UpdateEmbargo(ctx, uuid, version, embargoUntil)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.News.LoadTopics(ctx, changed); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, action, entities.AuditEntityNews, uuid, before, toNewsResponse(changed))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNewsResponse(changed), nil
+}
+
+func (uc *newsEmbargoUseCase) LiftDue(ctx context.Context, now time.Time, limit int) (lifted int, err error) {
+	now = now.UTC()
+
+	due, err := uc.newsRepo.GetDueEmbargoes(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	for _, news := range due {
+		err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+			if err := repos.News.LoadTopics(ctx, news); err != nil {
+				return err
+			}
+			before := toNewsResponse(news)
+
+			liftedNews, err := repos.News.LiftDueEmbargo(ctx, news.UUID, now)
+			if err != nil {
+				return err
+			}
+
+			if err := repos.News.LoadTopics(ctx, liftedNews); err != nil {
+				return err
+			}
+
+			return recordAudit(ctx, repos, entities.AuditActionEmbargoLift, entities.AuditEntityNews, news.UUID, before, toNewsResponse(liftedNews))
+		})
+
+		if errors.Is(err, common.ErrStaleVersion) {
+			// lifted or moved since it was listed
+			continue
+		} else if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		lifted++
+	}
+
+	return lifted, firstErr
+}
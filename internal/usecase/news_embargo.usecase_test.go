@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/delivery/data/dtos"
+)
+
+func (b backend) embargoUseCase() NewsEmbargoUseCase {
+	return NewNewsEmbargoUseCase(b.repos.News, b.repos.UnitOfWork, validator.New())
+}
+
+func TestEmbargoHidesNewsUntilLifted(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		public := testContext()
+		internal := common.WithInternal(public)
+		news := b.newsUseCase()
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+		created := createNews(t, news, "Final tonight", sport)
+
+		// published, with a working copy, so every read has something to show
+		current := created
+		for _, status := range []string{"in_review", "approved", "published"} {
+			var err error
+			current, err = news.UpdateNewsStatus(public, created.UUID, current.Version, dtos.UpdateNewsStatus{Status: status})
+			if err != nil {
+				t.Fatalf("move to %s: %v", status, err)
+			}
+		}
+		pending := NewNewsPendingEditUseCase(b.repos.News, b.repos.NewsPendingEdit, b.repos.UnitOfWork, validator.New())
+		b.lockNews(t, created.UUID)
+		if _, err := pending.StartPendingEdit(public, created.UUID, current.Version); err != nil {
+			t.Fatal(err)
+		}
+
+		embargoUntil := time.Now().Add(time.Hour)
+		request := dtos.EmbargoNewsRequest{EmbargoUntil: &embargoUntil}
+		if _, err := b.embargoUseCase().SetEmbargo(public, created.UUID, current.Version, request); !errors.Is(err, ErrEmbargoInternalOnly) {
+			t.Fatalf("public caller setting an embargo: got %v", err)
+		}
+		embargoed, err := b.embargoUseCase().SetEmbargo(internal, created.UUID, current.Version, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// listed reports whether the news is in the sport listing for ctx
+		listed := func(ctx context.Context) bool {
+			t.Helper()
+
+			value := "sport"
+			items, _, err := news.GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range items {
+				if item.UUID == created.UUID {
+					return true
+				}
+			}
+			return false
+		}
+
+		if listed(public) {
+			t.Error("embargoed news is listed to a public caller")
+		}
+		reads := map[string]func(ctx context.Context) error{
+			"detail": func(ctx context.Context) error {
+				_, err := news.GetByUuid(ctx, created.UUID)
+				return err
+			},
+			"revisions": func(ctx context.Context) error {
+				_, _, err := b.revisionUseCase().GetRevisions(ctx, created.UUID, firstPage())
+				return err
+			},
+			"pending edit": func(ctx context.Context) error {
+				_, err := pending.GetPendingEdit(ctx, created.UUID)
+				return err
+			},
+		}
+		for name, read := range reads {
+			if err := read(public); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("public read of the %s of embargoed news: got %v, want not found", name, err)
+			}
+		}
+
+		if !listed(internal) {
+			t.Error("embargoed news is not listed to an internal caller")
+		}
+		for name, read := range reads {
+			if err := read(internal); err != nil {
+				t.Errorf("internal read of the %s of embargoed news: %v", name, err)
+			}
+		}
+
+		// the lifter runs once the embargo has passed
+		lifted, err := b.embargoUseCase().LiftDue(public, embargoUntil.Add(time.Minute), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lifted != 1 {
+			t.Fatalf("lifted %d embargoes, want 1", lifted)
+		}
+
+		if !listed(public) {
+			t.Error("news is not listed once the embargo is lifted")
+		}
+		for name, read := range reads {
+			if err := read(public); err != nil {
+				t.Errorf("public read of the %s once the embargo is lifted: %v", name, err)
+			}
+		}
+		got, err := news.GetByUuid(public, created.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.EmbargoUntil != nil || got.Version != embargoed.Version+1 {
+			t.Errorf("lifted news has embargo %v at version %d, want none at version %d", got.EmbargoUntil, got.Version, embargoed.Version+1)
+		}
+
+		if lifted, err := b.embargoUseCase().LiftDue(public, embargoUntil.Add(time.Hour), 10); err != nil || lifted != 0 {
+			t.Errorf("lifting again: lifted %d, err %v", lifted, err)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsEmbargoUseCase interface {
+	// SetEmbargo and LiftEmbargo take the version the client last read and
+	// fail with common.ErrStaleVersion when the news was changed since.
+	SetEmbargo(ctx context.Context, uuid string, version int, dto dtos.EmbargoNewsRequest) (*response.NewsResponse, error)
+	// LiftEmbargo ends the embargo right away.
+	LiftEmbargo(ctx context.Context, uuid string, version int) (*response.NewsResponse, error)
+
+	// LiftDue lifts up to limit embargoes that have passed at now and
+	// returns how many it lifted. Items claimed by another worker in the
+	// meantime are skipped.
+	LiftDue(ctx context.Context, now time.Time, limit int) (lifted int, err error)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var ErrExpiresAtNotInFuture = errors.New("expires_at must be in the future")
+
+// ErrExpiresBeforePublish is returned when news would expire before its
+// scheduled publish time.
+var ErrExpiresBeforePublish = errors.New("expires_at must be after publish_at")
+
+type newsExpiryUseCase struct {
+	newsRepo repositories.NewsRepository
+	uow      repositories.UnitOfWork
+	validate *validator.Validate
+}
+
+func NewNewsExpiryUseCase(newsRepo repositories.NewsRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsExpiryUseCase {
+	return &newsExpiryUseCase{
+		newsRepo: newsRepo,
+		uow:      uow,
+		validate: validate,
+	}
+}
+
+func (uc *newsExpiryUseCase) SetExpiry(ctx context.Context, uuid string, version int, dto dtos.SetNewsExpiryRequest) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	if !dto.ExpiresAt.After(time.Now()) {
+		return nil, ErrExpiresAtNotInFuture
+	}
+
+	// UTC for the same reason as futurePublishAt
+	expiresAt := dto.ExpiresAt.UTC()
+
+	return uc.changeExpiry(ctx, uuid, version, func(news *entities.News, _ entities.TransitionContext) (entities.StatusType, *time.Time, error) {
+		if news.PublishAt != nil && news.Status == entities.NewsStatusScheduled && !expiresAt.After(*news.PublishAt) {
+			return "", nil, ErrExpiresBeforePublish
+		}
+		return news.Status, &expiresAt, nil
+	}, entities.AuditActionUpdate)
+}
+
+func (uc *newsExpiryUseCase) ClearExpiry(ctx context.Context, uuid string, version int) (*response.NewsResponse, error) {
+	return uc.changeExpiry(ctx, uuid, version, func(news *entities.News, _ entities.TransitionContext) (entities.StatusType, *time.Time, error) {
+		return news.Status, nil, nil
+	}, entities.AuditActionUpdate)
+}
+
+func (uc *newsExpiryUseCase) Unarchive(ctx context.Context, uuid string, version int) (*response.NewsResponse, error) {
+	return uc.changeExpiry(ctx, uuid, version, func(news *entities.News, tc entities.TransitionContext) (entities.StatusType, *time.Time, error) {
+		if news.ExpiresAt != nil && !news.ExpiresAt.After(tc.Now) {
+			news.ExpiresAt = nil
+		}
+
+		if err := news.CheckTransition(entities.NewsStatusPublished, tc); err != nil {
+			return "", nil, err
+		}
+		return entities.NewsStatusPublished, news.ExpiresAt, nil
+	}, entities.AuditActionStatusChange)
+}
+
+// changeExpiry writes the status and expiry time that change picks for the
+// current news, recording a revision and an audit event.
+func (uc *newsExpiryUseCase) changeExpiry(ctx context.Context, uuid string, version int, change func(news *entities.News, tc entities.TransitionContext) (entities.StatusType, *time.Time, error), action entities.AuditAction) (*response.NewsResponse, error) {
+	var changed *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existing, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, existing, time.Now()); err != nil {
+			return err
+		}
+
+		if existing.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if err := repos.News.LoadTopics(ctx, existing); err != nil {
+			return err
+		}
+		before := toNewsResponse(existing)
+
+		tc, err := transitionContext(ctx, repos.NewsReviewComment, existing, time.Now())
+		if err != nil {
+			return err
+		}
+
+		status, expiresAt, err := change(existing, tc)
+		if err != nil {
+			return err
+		}
+
+		changed, err = repos.News.UpdateExpiry(ctx, uuid, version, status, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, repos, changed); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, action, entities.AuditEntityNews, uuid, before, toNewsResponse(changed))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNewsResponse(changed), nil
+}
+
+func (uc *newsExpiryUseCase) ArchiveExpired(ctx context.Context, now time.Time, limit int) (archived int, err error) {
+	now = now.UTC()
+
+	expired, err := uc.newsRepo.GetDueExpired(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	for _, news := range expired {
+		if err := news.CheckTransition(entities.NewsStatusArchived, entities.TransitionContext{Now: now}); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+			if err := repos.News.LoadTopics(ctx, news); err != nil {
+				return err
+			}
+			before := toNewsResponse(news)
+
+			archivedNews, err := repos.News.ArchiveExpired(ctx, news.UUID, now)
+			if err != nil {
+				return err
+			}
+
+			if err := recordRevision(ctx, repos, archivedNews); err != nil {
+				return err
+			}
+
+			return recordAudit(ctx, repos, entities.AuditActionStatusChange, entities.AuditEntityNews, news.UUID, before, toNewsResponse(archivedNews))
+		})
+
+		if errors.Is(err, common.ErrStaleVersion) {
+			// archived, unpublished or given a new expiry since it was listed
+			continue
+		} else if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		archived++
+	}
+
+	return archived, firstErr
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsExpiryUseCase interface {
+	// SetExpiry, ClearExpiry and Unarchive take the version the client last
+	// read and fail with common.ErrStaleVersion when the news was changed
+	// since.
+	SetExpiry(ctx context.Context, uuid string, version int, dto dtos.SetNewsExpiryRequest) (*response.NewsResponse, error)
+	ClearExpiry(ctx context.Context, uuid string, version int) (*response.NewsResponse, error)
+	// Unarchive publishes archived news again. An expiry time that has
+	// already passed is cleared so the news is not archived right away.
+	Unarchive(ctx context.Context, uuid string, version int) (*response.NewsResponse, error)
+
+	// ArchiveExpired archives up to limit published news that have expired
+	// at now and returns how many it archived. Items claimed by another
+	// expirer in the meantime are skipped.
+	ArchiveExpired(ctx context.Context, now time.Time, limit int) (archived int, err error)
+}
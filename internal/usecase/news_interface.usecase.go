@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"news-topic-api/common"
 
 	"news-topic-api/internal/delivery/data/dtos"
@@ -8,11 +9,19 @@ import (
 )
 
 type NewsUseCase interface {
-	GetAllNews(pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*response.NewsResponse, totalItems int, err error)
-	CreateNews(newsDto dtos.CreateNewsRequest) (news *response.NewsResponse, err error)
-	GetByUuid(uuid string) (news *response.NewsResponse, err error)
-	UpdateByUuid(uuid string, newsDto dtos.UpdateNewsRequest) (*response.NewsResponse, error)
-	DeleteByUuid(uuid string) error
+	GetAllNews(ctx context.Context, pagination *common.Pagination, filter *dtos.FilterNewsRequest) (news []*response.NewsResponse, totalItems int, err error)
+	CreateNews(ctx context.Context, newsDto dtos.CreateNewsRequest) (news *response.NewsResponse, err error)
+	GetByUuid(ctx context.Context, uuid string) (news *response.NewsResponse, err error)
+	// The writes below take the version the client last read and fail with
+	// common.ErrStaleVersion when the news was changed since.
+	UpdateByUuid(ctx context.Context, uuid string, version int, newsDto dtos.UpdateNewsRequest) (*response.NewsResponse, error)
+	DeleteByUuid(ctx context.Context, uuid string, version int) error
 
-	UpdateNewsStatus(uuid string, dto dtos.UpdateNewsStatus) (*response.NewsResponse, error)
+	UpdateNewsStatus(ctx context.Context, uuid string, version int, dto dtos.UpdateNewsStatus) (*response.NewsResponse, error)
+	// GetTransitions lists the statuses the news can move to next.
+	GetTransitions(ctx context.Context, uuid string) (*response.NewsTransitionsResponse, error)
+
+	GetTrashedNews(ctx context.Context, pagination *common.Pagination) (news []*response.NewsResponse, totalItems int, err error)
+	RestoreByUuid(ctx context.Context, uuid string) (*response.NewsResponse, error)
+	PurgeByUuid(ctx context.Context, uuid string) error
 }
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-topic-api/common"
+	"time"
+
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// ErrNewsLocked is wrapped by NewsLockedError.
+var ErrNewsLocked = errors.New("news is locked")
+
+var ErrNewsNotLocked = errors.New("news is not locked")
+
+// ErrLockNotHeld is returned when renewing or releasing a lock the actor
+// does not hold, for instance because its lease ran out.
+var ErrLockNotHeld = errors.New("you do not hold the lock on this news")
+
+// ErrLockActorRequired is returned when an anonymous request tries to lock,
+// as every anonymous caller would share the lock.
+var ErrLockActorRequired = errors.New("locking news needs an X-Actor header")
+
+var ErrAdminRequired = errors.New("only admins can break a lock")
+
+// ErrEditLockRequired is returned when editing news without holding its
+// lock.
+var ErrEditLockRequired = errors.New("lock the news with POST /news/{uuid}/lock before editing it")
+
+// NewsLockedError is returned when someone else holds the lock on the news
+// being locked or edited.
+type NewsLockedError struct {
+	Holder    string
+	ExpiresAt time.Time
+}
+
+func (e *NewsLockedError) Error() string {
+	return fmt.Sprintf("news is locked by %s until %s", e.Holder, e.ExpiresAt.Format(time.RFC3339))
+}
+
+func (e *NewsLockedError) Unwrap() error {
+	return ErrNewsLocked
+}
+
+type newsLockUseCase struct {
+	newsRepo repositories.NewsRepository
+	lockRepo repositories.NewsLockRepository
+	uow      repositories.UnitOfWork
+	ttl      time.Duration
+}
+
+func NewNewsLockUseCase(newsRepo repositories.NewsRepository, lockRepo repositories.NewsLockRepository, uow repositories.UnitOfWork, ttl time.Duration) NewsLockUseCase {
+	return &newsLockUseCase{
+		newsRepo: newsRepo,
+		lockRepo: lockRepo,
+		uow:      uow,
+		ttl:      ttl,
+	}
+}
+
+func (uc *newsLockUseCase) GetLock(ctx context.Context, uuid string) (*response.NewsLockResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := uc.lockRepo.GetLock(ctx, news.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lock == nil || !lock.HeldAt(time.Now()) {
+		return nil, ErrNewsNotLocked
+	}
+
+	return toNewsLockResponse(lock), nil
+}
+
+func (uc *newsLockUseCase) Lock(ctx context.Context, uuid string) (*response.NewsLockResponse, error) {
+	actor := common.ActorFrom(ctx)
+	if actor == common.AnonymousActor {
+		return nil, ErrLockActorRequired
+	}
+
+	news, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	// renewing first keeps acquired_at when the actor locks again
+	if renewed, err := uc.lockRepo.RenewLock(ctx, news.Id, actor, now.Add(uc.ttl), now); err != nil {
+		return nil, err
+	} else if renewed {
+		return uc.currentLock(ctx, news.Id)
+	}
+
+	acquired, err := uc.lockRepo.AcquireLock(ctx, &entities.NewsLock{
+		NewsId:     news.Id,
+		Holder:     actor,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(uc.ttl),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acquired {
+		lock, err := uc.lockRepo.GetLock(common.WithPrimaryReads(ctx), news.Id)
+		if err != nil {
+			return nil, err
+		}
+		if lock == nil {
+			// released between the two queries
+			return nil, ErrNewsLocked
+		}
+
+		return nil, &NewsLockedError{Holder: lock.Holder, ExpiresAt: lock.ExpiresAt}
+	}
+
+	return uc.currentLock(ctx, news.Id)
+}
+
+func (uc *newsLockUseCase) Heartbeat(ctx context.Context, uuid string) (*response.NewsLockResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	renewed, err := uc.lockRepo.RenewLock(ctx, news.Id, common.ActorFrom(ctx), now.Add(uc.ttl), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !renewed {
+		return nil, ErrLockNotHeld
+	}
+
+	return uc.currentLock(ctx, news.Id)
+}
+
+func (uc *newsLockUseCase) Unlock(ctx context.Context, uuid string) error {
+	news, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
+		return err
+	}
+
+	released, err := uc.lockRepo.ReleaseLock(ctx, news.Id, common.ActorFrom(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !released {
+		return uc.notReleased(ctx, news.Id)
+	}
+
+	return nil
+}
+
+// BreakLock is recorded in the audit log with the broken lock as the
+// before state, so the holder can find out who took their lock away.
+func (uc *newsLockUseCase) BreakLock(ctx context.Context, uuid string) error {
+	if !common.IsAdmin(ctx) {
+		return ErrAdminRequired
+	}
+
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		news, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		lock, err := repos.NewsLock.GetLock(common.WithPrimaryReads(ctx), news.Id)
+		if err != nil {
+			return err
+		}
+		if lock == nil {
+			return ErrNewsNotLocked
+		}
+
+		if _, err := repos.NewsLock.ReleaseLock(ctx, news.Id, ""); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionLockBreak, entities.AuditEntityNews, uuid, toNewsLockResponse(lock), nil)
+	})
+}
+
+func (uc *newsLockUseCase) currentLock(ctx context.Context, newsId uint) (*response.NewsLockResponse, error) {
+	lock, err := uc.lockRepo.GetLock(common.WithPrimaryReads(ctx), newsId)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, ErrLockNotHeld
+	}
+
+	return toNewsLockResponse(lock), nil
+}
+
+// notReleased tells apart unlocking news nobody locked from unlocking
+// someone else's lock.
+func (uc *newsLockUseCase) notReleased(ctx context.Context, newsId uint) error {
+	lock, err := uc.lockRepo.GetLock(common.WithPrimaryReads(ctx), newsId)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		return ErrNewsNotLocked
+	}
+
+	return ErrLockNotHeld
+}
+
+// checkEditLock rejects editing news unless the actor holds a running lock
+// on it.
+func checkEditLock(ctx context.Context, repos *repositories.Repositories, news *entities.News, now time.Time) error {
+	lock, err := repos.NewsLock.GetLock(common.WithPrimaryReads(ctx), news.Id)
+	if err != nil {
+		return err
+	}
+
+	if lock == nil || !lock.HeldAt(now) {
+		return ErrEditLockRequired
+	}
+
+	if lock.Holder != common.ActorFrom(ctx) {
+		return &NewsLockedError{Holder: lock.Holder, ExpiresAt: lock.ExpiresAt}
+	}
+
+	return nil
+}
+
+// checkForeignLock only rejects changing news that someone other than the
+// actor holds a running lock on. Bulk actions use it, as nobody can lock
+// every item they touch.
+func checkForeignLock(ctx context.Context, repos *repositories.Repositories, news *entities.News, now time.Time) error {
+	lock, err := repos.NewsLock.GetLock(common.WithPrimaryReads(ctx), news.Id)
+	if err != nil {
+		return err
+	}
+
+	if lock != nil && lock.HeldAt(now) && lock.Holder != common.ActorFrom(ctx) {
+		return &NewsLockedError{Holder: lock.Holder, ExpiresAt: lock.ExpiresAt}
+	}
+
+	return nil
+}
+
+func toNewsLockResponse(lock *entities.NewsLock) *response.NewsLockResponse {
+	return &response.NewsLockResponse{
+		Holder:     lock.Holder,
+		AcquiredAt: lock.AcquiredAt,
+		ExpiresAt:  lock.ExpiresAt,
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+// lockLease is short enough for a test to wait it out.
+const lockLease = 300 * time.Millisecond
+
+// lockStep is one call an editor makes, after waiting for wait.
+type lockStep struct {
+	wait  time.Duration
+	actor string
+	admin bool
+	op    string
+	err   error
+}
+
+func TestNewsLocks(t *testing.T) {
+	cases := map[string][]lockStep{
+		"lease expires": {
+			{actor: "alice", op: "lock"},
+			{actor: "bob", op: "lock", err: ErrNewsLocked},
+			{wait: lockLease + 100*time.Millisecond, actor: "alice", op: "heartbeat", err: ErrLockNotHeld},
+			{actor: "bob", op: "lock"},
+			{actor: "alice", op: "edit", err: ErrNewsLocked},
+		},
+		"heartbeat extends the lease": {
+			{actor: "alice", op: "lock"},
+			{wait: lockLease * 2 / 3, actor: "alice", op: "heartbeat"},
+			{wait: lockLease * 2 / 3, actor: "bob", op: "lock", err: ErrNewsLocked},
+			{actor: "alice", op: "heartbeat"},
+		},
+		"non-holder is refused": {
+			{actor: "alice", op: "edit", err: ErrEditLockRequired},
+			{actor: "alice", op: "lock"},
+			{actor: "bob", op: "edit", err: ErrNewsLocked},
+			{actor: "bob", op: "heartbeat", err: ErrLockNotHeld},
+			{actor: "bob", op: "unlock", err: ErrLockNotHeld},
+			{actor: "bob", op: "break", err: ErrAdminRequired},
+			{actor: "alice", op: "edit"},
+			{actor: "alice", op: "unlock"},
+			{actor: "bob", op: "lock"},
+		},
+		"admin breaks the lock": {
+			{actor: "chief", admin: true, op: "break", err: ErrNewsNotLocked},
+			{actor: "alice", op: "lock"},
+			{actor: "chief", admin: true, op: "break"},
+			{actor: "alice", op: "heartbeat", err: ErrLockNotHeld},
+			{actor: "bob", op: "lock"},
+			{actor: "bob", op: "edit"},
+		},
+	}
+
+	for name, steps := range cases {
+		t.Run(name, func(t *testing.T) {
+			eachBackend(t, func(t *testing.T, b backend) {
+				news := b.newsUseCase()
+				locks := NewNewsLockUseCase(b.repos.News, b.repos.NewsLock, b.repos.UnitOfWork, lockLease)
+				created := createNews(t, news, "Final tonight")
+
+				for i, step := range steps {
+					time.Sleep(step.wait)
+
+					ctx := common.WithActor(context.Background(), step.actor)
+					if step.admin {
+						ctx = common.WithAdmin(ctx)
+					}
+
+					var err error
+					switch step.op {
+					case "lock":
+						_, err = locks.Lock(ctx, created.UUID)
+					case "heartbeat":
+						_, err = locks.Heartbeat(ctx, created.UUID)
+					case "unlock":
+						err = locks.Unlock(ctx, created.UUID)
+					case "break":
+						err = locks.BreakLock(ctx, created.UUID)
+					case "edit":
+						var current *response.NewsResponse
+						current, err = news.GetByUuid(ctx, created.UUID)
+						if err != nil {
+							t.Fatal(err)
+						}
+						_, err = news.UpdateByUuid(ctx, created.UUID, current.Version, dtos.UpdateNewsRequest{Title: "Final by " + step.actor})
+					}
+
+					if !errors.Is(err, step.err) {
+						t.Fatalf("step %d, %s by %s: got %v, want %v", i, step.op, step.actor, err, step.err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+// NewsLockUseCase checks news out for editing. The lock belongs to the
+// actor of the request and lasts for a lease that heartbeats extend.
+type NewsLockUseCase interface {
+	// GetLock returns the running lock, or ErrNewsNotLocked.
+	GetLock(ctx context.Context, uuid string) (*response.NewsLockResponse, error)
+	// Lock takes the lock, or extends it when the actor already holds it.
+	// It fails with a *NewsLockedError while someone else holds it.
+	Lock(ctx context.Context, uuid string) (*response.NewsLockResponse, error)
+	// Heartbeat extends the actor's lock. It fails with ErrLockNotHeld once
+	// the lease has run out, even if nobody took the lock over.
+	Heartbeat(ctx context.Context, uuid string) (*response.NewsLockResponse, error)
+	// Unlock checks the news back in.
+	Unlock(ctx context.Context, uuid string) error
+	// BreakLock removes the lock whoever holds it. Only admins may break
+	// locks.
+	BreakLock(ctx context.Context, uuid string) error
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// ErrNewsNotPublished is returned when starting or publishing a pending edit
+// of news that is not published. Unpublished news is edited directly.
+var ErrNewsNotPublished = errors.New("only published news has pending edits")
+
+var ErrPendingEditExists = errors.New("news already has a pending edit")
+
+// ErrPendingEditUnresolved is returned when publishing a pending edit while
+// review comments are open, which would block publishing the news too.
+var ErrPendingEditUnresolved = errors.New("pending edit cannot be published")
+
+// isPendingEditNotFound tells a pending edit lookup that found nothing apart
+// from one that failed.
+func isPendingEditNotFound(err error) bool {
+	return err != nil && (errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "pending edit not found")
+}
+
+type newsPendingEditUseCase struct {
+	newsRepo    repositories.NewsRepository
+	pendingRepo repositories.NewsPendingEditRepository
+	uow         repositories.UnitOfWork
+	validate    *validator.Validate
+}
+
+func NewNewsPendingEditUseCase(newsRepo repositories.NewsRepository, pendingRepo repositories.NewsPendingEditRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsPendingEditUseCase {
+	return &newsPendingEditUseCase{
+		newsRepo:    newsRepo,
+		pendingRepo: pendingRepo,
+		uow:         uow,
+		validate:    validate,
+	}
+}
+
+func (uc *newsPendingEditUseCase) GetPendingEdit(ctx context.Context, uuid string) (*response.NewsPendingEditResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	edit, err := uc.pendingRepo.GetPendingEdit(ctx, news.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPendingEditResponse(news, edit), nil
+}
+
+func (uc *newsPendingEditUseCase) StartPendingEdit(ctx context.Context, uuid string, version int) (*response.NewsPendingEditResponse, error) {
+	var news *entities.News
+	var started *entities.NewsPendingEdit
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		var err error
+		news, err = repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		if news.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if news.Status != entities.NewsStatusPublished {
+			return ErrNewsNotPublished
+		}
+
+		if err := checkEditLock(ctx, repos, news, time.Now()); err != nil {
+			return err
+		}
+
+		if _, err := repos.NewsPendingEdit.GetPendingEdit(common.WithPrimaryReads(ctx), news.Id); err == nil {
+			return ErrPendingEditExists
+		} else if !isPendingEditNotFound(err) {
+			return err
+		}
+
+		if err := repos.News.LoadTopics(ctx, news); err != nil {
+			return err
+		}
+
+		started, err = repos.NewsPendingEdit.CreatePendingEdit(ctx, &entities.NewsPendingEdit{
+			NewsId:    news.Id,
+			Title:     news.Title,
+			Content:   news.Content,
+			Language:  news.Language,
+			Topics:    revisionTopics(news.Topics),
+			UpdatedBy: common.ActorFrom(ctx),
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPendingEditResponse(news, started), nil
+}
+
+func (uc *newsPendingEditUseCase) UpdatePendingEdit(ctx context.Context, uuid string, version int, dto dtos.EditPendingNewsRequest) (*response.NewsPendingEditResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	var news *entities.News
+	var updated *entities.NewsPendingEdit
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		var err error
+		news, err = repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		if err := checkEditLock(ctx, repos, news, time.Now()); err != nil {
+			return err
+		}
+
+		edit, err := repos.NewsPendingEdit.GetPendingEdit(common.WithPrimaryReads(ctx), news.Id)
+		if err != nil {
+			return err
+		}
+
+		if edit.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if dto.Title != "" {
+			edit.Title = dto.Title
+		}
+		if dto.Content != "" {
+			edit.Content = dto.Content
+		}
+		if dto.Language != "" {
+			edit.Language = entities.LanguageType(dto.Language)
+		}
+
+		if dto.Topics != nil {
+			topics := []entities.Topic{}
+			for _, topicDto := range dto.Topics {
+				topic, err := repos.Topic.GetByUuid(ctx, topicDto.Uuid)
+				if err != nil {
+					return err
+				}
+				topics = append(topics, *topic)
+			}
+			edit.Topics = revisionTopics(topics)
+		}
+
+		edit.UpdatedBy = common.ActorFrom(ctx)
+
+		updated, err = repos.NewsPendingEdit.UpdatePendingEdit(ctx, edit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPendingEditResponse(news, updated), nil
+}
+
+func (uc *newsPendingEditUseCase) DiscardPendingEdit(ctx context.Context, uuid string, version int) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		news, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		if err := checkEditLock(ctx, repos, news, time.Now()); err != nil {
+			return err
+		}
+
+		// tells a missing copy (404) apart from a stale version (412)
+		if _, err := repos.NewsPendingEdit.GetPendingEdit(common.WithPrimaryReads(ctx), news.Id); err != nil {
+			return err
+		}
+
+		return repos.NewsPendingEdit.DeletePendingEdit(ctx, news.Id, version)
+	})
+}
+
+// PublishPendingEdit swaps the working copy in as the live content. Topics
+// deleted since they were picked are left out. Readers see either the old
+// or the new content, never a mix, and the swap is recorded as a revision.
+func (uc *newsPendingEditUseCase) PublishPendingEdit(ctx context.Context, uuid string, version int) (*response.NewsResponse, error) {
+	var published *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		news, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		if news.Status != entities.NewsStatusPublished {
+			return ErrNewsNotPublished
+		}
+
+		if err := checkEditLock(ctx, repos, news, time.Now()); err != nil {
+			return err
+		}
+
+		edit, err := repos.NewsPendingEdit.GetPendingEdit(common.WithPrimaryReads(ctx), news.Id)
+		if err != nil {
+			return err
+		}
+
+		if edit.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		tc, err := transitionContext(ctx, repos.NewsReviewComment, news, time.Now())
+		if err != nil {
+			return err
+		}
+		if reason := tc.UnresolvedCommentsReason(); reason != "" {
+			return fmt.Errorf("%w: %s", ErrPendingEditUnresolved, reason)
+		}
+
+		if err := repos.News.LoadTopics(ctx, news); err != nil {
+			return err
+		}
+		before := toNewsResponse(news)
+
+		news.Title = edit.Title
+		news.Content = edit.Content
+		news.Language = edit.Language
+
+		published, err = repos.News.UpdateByUuid(ctx, uuid, news)
+		if err != nil {
+			return err
+		}
+
+		topicEntities, err := liveTopics(ctx, repos, edit.Topics)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.News.ReplaceTopics(ctx, published, topicEntities); err != nil {
+			return err
+		}
+
+		if err := repos.NewsPendingEdit.DeletePendingEdit(ctx, news.Id, edit.Version); err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, repos, published); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityNews, uuid, before, toNewsResponse(published))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNewsResponse(published), nil
+}
+
+func toPendingEditResponse(news *entities.News, edit *entities.NewsPendingEdit) *response.NewsPendingEditResponse {
+	topics := make([]response.TopicResponse, len(edit.Topics))
+	for i, topic := range edit.Topics {
+		topics[i] = response.TopicResponse{
+			Id:    topic.Id,
+			UUID:  topic.UUID,
+			Title: topic.Title,
+			Value: topic.Value,
+		}
+	}
+
+	return &response.NewsPendingEditResponse{
+		NewsUUID:  news.UUID,
+		Title:     edit.Title,
+		Content:   edit.Content,
+		Language:  string(edit.Language),
+		Topics:    topics,
+		Version:   edit.Version,
+		UpdatedBy: edit.UpdatedBy,
+		CreatedAt: edit.CreatedAt,
+		UpdatedAt: edit.UpdatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// failingPendingEditLookup fails every pending edit lookup, as a dropped
+// connection would.
+type failingPendingEditLookup struct {
+	repositories.NewsPendingEditRepository
+}
+
+func (failingPendingEditLookup) GetPendingEdit(ctx context.Context, newsId uint) (*entities.NewsPendingEdit, error) {
+	return nil, errInjected
+}
+
+func TestStartPendingEdit(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		created := createNews(t, news, "Final tonight")
+
+		current := created
+		for _, status := range []string{"in_review", "approved", "published"} {
+			var err error
+			current, err = news.UpdateNewsStatus(ctx, created.UUID, current.Version, dtos.UpdateNewsStatus{Status: status})
+			if err != nil {
+				t.Fatalf("move to %s: %v", status, err)
+			}
+		}
+		b.lockNews(t, created.UUID)
+
+		failing := &failingUnitOfWork{UnitOfWork: b.repos.UnitOfWork, wrap: func(repos *repositories.Repositories) {
+			repos.NewsPendingEdit = failingPendingEditLookup{repos.NewsPendingEdit}
+		}}
+		// a lookup that failed is not a lookup that found nothing
+		if _, err := NewNewsPendingEditUseCase(b.repos.News, b.repos.NewsPendingEdit, failing, validator.New()).StartPendingEdit(ctx, created.UUID, current.Version); !errors.Is(err, errInjected) {
+			t.Fatalf("start with a failing lookup: got %v, want the lookup error", err)
+		}
+
+		pending := NewNewsPendingEditUseCase(b.repos.News, b.repos.NewsPendingEdit, b.repos.UnitOfWork, validator.New())
+		if _, err := pending.GetPendingEdit(ctx, created.UUID); !isPendingEditNotFound(err) {
+			t.Fatalf("pending edit after a failed start: got %v, want not found", err)
+		}
+		if _, err := pending.StartPendingEdit(ctx, created.UUID, current.Version); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pending.StartPendingEdit(ctx, created.UUID, current.Version); !errors.Is(err, ErrPendingEditExists) {
+			t.Fatalf("starting twice: got %v, want ErrPendingEditExists", err)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+// NewsPendingEditUseCase edits published news through a working copy that
+// readers do not see until it is published.
+type NewsPendingEditUseCase interface {
+	GetPendingEdit(ctx context.Context, uuid string) (*response.NewsPendingEditResponse, error)
+	// StartPendingEdit copies the live content into a new working copy.
+	// version is the version of the live news the client last read.
+	StartPendingEdit(ctx context.Context, uuid string, version int) (*response.NewsPendingEditResponse, error)
+	// UpdatePendingEdit, DiscardPendingEdit and PublishPendingEdit take the
+	// version of the working copy the client last read and fail with
+	// common.ErrStaleVersion when it was saved since.
+	UpdatePendingEdit(ctx context.Context, uuid string, version int, dto dtos.EditPendingNewsRequest) (*response.NewsPendingEditResponse, error)
+	DiscardPendingEdit(ctx context.Context, uuid string, version int) error
+	// PublishPendingEdit replaces the live content with the working copy and
+	// removes the copy, in one transaction.
+	PublishPendingEdit(ctx context.Context, uuid string, version int) (*response.NewsResponse, error)
+}
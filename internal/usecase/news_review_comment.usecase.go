@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var ErrCommentAnchorOutOfRange = errors.New("anchor is outside the news content")
+
+// ErrReplyAnchored is returned for a reply with an anchor. A thread is
+// anchored by its first comment.
+var ErrReplyAnchored = errors.New("replies cannot be anchored")
+
+// ErrCommentNotThread is returned when resolving or reopening a reply
+// instead of the comment that starts the thread.
+var ErrCommentNotThread = errors.New("only the first comment of a thread can be resolved or reopened")
+
+var ErrThreadResolved = errors.New("comment thread is already resolved")
+
+var ErrThreadNotResolved = errors.New("comment thread is not resolved")
+
+// ErrNewsScheduledThread is returned when opening a thread on scheduled
+// news. The publisher cannot publish news with an open thread, so the
+// schedule has to be cancelled first.
+var ErrNewsScheduledThread = errors.New("news is scheduled, cancel the schedule before opening a review thread")
+
+type newsReviewCommentUseCase struct {
+	newsRepo    repositories.NewsRepository
+	commentRepo repositories.NewsReviewCommentRepository
+	validate    *validator.Validate
+}
+
+func NewNewsReviewCommentUseCase(newsRepo repositories.NewsRepository, commentRepo repositories.NewsReviewCommentRepository, validate *validator.Validate) NewsReviewCommentUseCase {
+	return &newsReviewCommentUseCase{
+		newsRepo:    newsRepo,
+		commentRepo: commentRepo,
+		validate:    validate,
+	}
+}
+
+func (uc *newsReviewCommentUseCase) GetComments(ctx context.Context, newsUuid string) (*response.NewsReviewCommentsResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := uc.commentRepo.GetComments(ctx, news.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	commentsResponse := &response.NewsReviewCommentsResponse{
+		Threads: []*response.NewsReviewCommentResponse{},
+	}
+
+	// comments come oldest first, so a parent is always seen before its
+	// replies
+	byId := map[uint]*response.NewsReviewCommentResponse{}
+	for _, comment := range comments {
+		commentResponse := newsReviewCommentResponse(news, comment)
+		byId[comment.Id] = commentResponse
+
+		if comment.ParentId != nil {
+			if parent, ok := byId[*comment.ParentId]; ok {
+				parent.Replies = append(parent.Replies, commentResponse)
+				continue
+			}
+		}
+
+		commentsResponse.Threads = append(commentsResponse.Threads, commentResponse)
+		if !comment.Resolved() {
+			commentsResponse.Unresolved++
+		}
+	}
+
+	return commentsResponse, nil
+}
+
+func (uc *newsReviewCommentUseCase) CreateComment(ctx context.Context, newsUuid string, dto dtos.CreateNewsReviewCommentRequest) (*response.NewsReviewCommentResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entities.NewsReviewComment{
+		NewsId: news.Id,
+		Author: common.ActorFrom(ctx),
+		Body:   dto.Body,
+	}
+
+	if dto.ParentUuid == "" && news.Status == entities.NewsStatusScheduled {
+		return nil, ErrNewsScheduledThread
+	}
+
+	if dto.ParentUuid != "" {
+		if dto.Anchor != nil {
+			return nil, ErrReplyAnchored
+		}
+
+		parent, err := uc.commentRepo.GetComment(ctx, news.Id, dto.ParentUuid)
+		if err != nil {
+			return nil, err
+		}
+		comment.ParentId = &parent.Id
+	}
+
+	if dto.Anchor != nil {
+		content := []rune(news.Content)
+		if dto.Anchor.End > len(content) {
+			return nil, ErrCommentAnchorOutOfRange
+		}
+
+		comment.AnchorStart = &dto.Anchor.Start
+		comment.AnchorEnd = &dto.Anchor.End
+		comment.AnchorText = string(content[dto.Anchor.Start:dto.Anchor.End])
+	}
+
+	created, err := uc.commentRepo.CreateComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return newsReviewCommentResponse(news, created), nil
+}
+
+func (uc *newsReviewCommentUseCase) ResolveComment(ctx context.Context, newsUuid string, commentUuid string) (*response.NewsReviewCommentResponse, error) {
+	return uc.setResolved(ctx, newsUuid, commentUuid, true)
+}
+
+func (uc *newsReviewCommentUseCase) ReopenComment(ctx context.Context, newsUuid string, commentUuid string) (*response.NewsReviewCommentResponse, error) {
+	return uc.setResolved(ctx, newsUuid, commentUuid, false)
+}
+
+func (uc *newsReviewCommentUseCase) setResolved(ctx context.Context, newsUuid string, commentUuid string, resolved bool) (*response.NewsReviewCommentResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := uc.commentRepo.GetComment(ctx, news.Id, commentUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.ParentId != nil {
+		return nil, ErrCommentNotThread
+	}
+
+	var resolvedAt *time.Time
+	var resolvedBy string
+	if resolved {
+		if comment.Resolved() {
+			return nil, ErrThreadResolved
+		}
+		now := time.Now()
+		resolvedAt = &now
+		resolvedBy = common.ActorFrom(ctx)
+	} else if !comment.Resolved() {
+		return nil, ErrThreadNotResolved
+	} else if news.Status == entities.NewsStatusScheduled {
+		return nil, ErrNewsScheduledThread
+	}
+
+	updated, err := uc.commentRepo.SetResolved(ctx, comment, resolvedAt, resolvedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return newsReviewCommentResponse(news, updated), nil
+}
+
+func newsReviewCommentResponse(news *entities.News, comment *entities.NewsReviewComment) *response.NewsReviewCommentResponse {
+	commentResponse := &response.NewsReviewCommentResponse{
+		UUID:       comment.UUID,
+		Author:     comment.Author,
+		Body:       comment.Body,
+		Resolved:   comment.Resolved(),
+		ResolvedAt: comment.ResolvedAt,
+		ResolvedBy: comment.ResolvedBy,
+		CreatedAt:  comment.CreatedAt,
+		Replies:    []*response.NewsReviewCommentResponse{},
+	}
+
+	if comment.AnchorStart != nil && comment.AnchorEnd != nil {
+		start, end := *comment.AnchorStart, *comment.AnchorEnd
+
+		content := []rune(news.Content)
+		outdated := end > len(content) || string(content[start:end]) != comment.AnchorText
+
+		commentResponse.Anchor = &response.NewsCommentAnchorResponse{
+			Start:    start,
+			End:      end,
+			Text:     comment.AnchorText,
+			Outdated: outdated,
+		}
+	}
+
+	return commentResponse
+}
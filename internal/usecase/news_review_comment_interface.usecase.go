@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsReviewCommentUseCase interface {
+	// GetComments returns the comment threads of a news item, oldest first,
+	// with the replies nested under the comment they answer.
+	GetComments(ctx context.Context, newsUuid string) (*response.NewsReviewCommentsResponse, error)
+	CreateComment(ctx context.Context, newsUuid string, dto dtos.CreateNewsReviewCommentRequest) (*response.NewsReviewCommentResponse, error)
+	// ResolveComment and ReopenComment act on a whole thread, through its
+	// root comment.
+	ResolveComment(ctx context.Context, newsUuid string, commentUuid string) (*response.NewsReviewCommentResponse, error)
+	ReopenComment(ctx context.Context, newsUuid string, commentUuid string) (*response.NewsReviewCommentResponse, error)
+}
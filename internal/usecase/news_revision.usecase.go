@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+type newsRevisionUseCase struct {
+	newsRepo     repositories.NewsRepository
+	revisionRepo repositories.NewsRevisionRepository
+	uow          repositories.UnitOfWork
+	validate     *validator.Validate
+}
+
+func NewNewsRevisionUseCase(newsRepo repositories.NewsRepository, revisionRepo repositories.NewsRevisionRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsRevisionUseCase {
+	return &newsRevisionUseCase{
+		newsRepo:     newsRepo,
+		revisionRepo: revisionRepo,
+		uow:          uow,
+		validate:     validate,
+	}
+}
+
+func (uc *newsRevisionUseCase) GetRevisions(ctx context.Context, newsUuid string, pagination *common.Pagination) (revisions []*response.NewsRevisionResponse, totalItems int, err error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisionEntities, totalItems64, err := uc.revisionRepo.GetRevisions(ctx, news.Id, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisions = []*response.NewsRevisionResponse{}
+	for _, revision := range revisionEntities {
+		revisions = append(revisions, newsRevisionResponse(revision))
+	}
+
+	return revisions, int(totalItems64), nil
+}
+
+func (uc *newsRevisionUseCase) GetRevision(ctx context.Context, newsUuid string, revision int) (*response.NewsRevisionResponse, error) {
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	revisionEntity, err := uc.revisionRepo.GetRevision(ctx, news.Id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return newsRevisionResponse(revisionEntity), nil
+}
+
+func (uc *newsRevisionUseCase) DiffRevisions(ctx context.Context, newsUuid string, dto dtos.DiffNewsRevisionsRequest) (*response.NewsRevisionDiffResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	if dto.Mode == "" {
+		dto.Mode = "line"
+	}
+
+	news, err := getVisibleNews(ctx, uc.newsRepo, newsUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := uc.revisionRepo.GetRevision(ctx, news.Id, dto.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := uc.revisionRepo.GetRevision(ctx, news.Id, dto.To)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := common.DiffLines
+	if dto.Mode == "word" {
+		diff = common.DiffWords
+	}
+
+	title, err := diff(from.Title, to.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := diff(from.Content, to.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	diffResponse := &response.NewsRevisionDiffResponse{
+		From:          from.Revision,
+		To:            to.Revision,
+		Mode:          dto.Mode,
+		Title:         title,
+		Content:       content,
+		TopicsAdded:   revisionTopicsMissing(to.Topics, from.Topics),
+		TopicsRemoved: revisionTopicsMissing(from.Topics, to.Topics),
+	}
+
+	if from.Status != to.Status {
+		diffResponse.Status = &response.ValueChange{From: string(from.Status), To: string(to.Status)}
+	}
+	if from.Language != to.Language {
+		diffResponse.Language = &response.ValueChange{From: string(from.Language), To: string(to.Language)}
+	}
+
+	return diffResponse, nil
+}
+
+// RestoreRevision copies a past revision back onto the news item as a draft.
+// Topics deleted since the revision was taken are left out. The restore is
+// itself recorded as a new revision.
+func (uc *newsRevisionUseCase) RestoreRevision(ctx context.Context, newsUuid string, revision int, version int) (*response.NewsResponse, error) {
+	var restored *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		news, err := repos.News.GetByUuid(ctx, newsUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, news, time.Now()); err != nil {
+			return err
+		}
+
+		revisionEntity, err := repos.NewsRevision.GetRevision(ctx, news.Id, revision)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEditLock(ctx, repos, news, time.Now()); err != nil {
+			return err
+		}
+
+		if news.Status != entities.NewsStatusDraft {
+			if err := news.CheckTransition(entities.NewsStatusDraft, entities.TransitionContext{Now: time.Now()}); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.News.LoadTopics(ctx, news); err != nil {
+			return err
+		}
+		before := toNewsResponse(news)
+
+		news.Title = revisionEntity.Title
+		news.Content = revisionEntity.Content
+		news.Language = revisionEntity.Language
+		news.Status = entities.NewsStatusDraft
+		news.Version = version
+
+		restored, err = repos.News.UpdateByUuid(ctx, newsUuid, news)
+		if err != nil {
+			return err
+		}
+
+		topicEntities, err := liveTopics(ctx, repos, revisionEntity.Topics)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.News.ReplaceTopics(ctx, restored, topicEntities); err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, repos, restored); err != nil {
+			return err
+		}
+
+		if err := repos.News.LoadAuthors(ctx, restored); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityNews, newsUuid, before, toNewsResponse(restored))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNewsResponse(restored), nil
+}
+
+// recordRevision snapshots the news item inside the unit of work that wrote
+// it, so a revision exists exactly when its write was committed.
+func recordRevision(ctx context.Context, repos *repositories.Repositories, news *entities.News) error {
+	if err := repos.News.LoadTopics(ctx, news); err != nil {
+		return err
+	}
+
+	_, err := repos.NewsRevision.CreateRevision(ctx, &entities.NewsRevision{
+		NewsId:   news.Id,
+		Title:    news.Title,
+		Content:  news.Content,
+		Status:   news.Status,
+		Language: news.Language,
+		Topics:   revisionTopics(news.Topics),
+	})
+
+	return err
+}
+
+// revisionTopics snapshots topics as they are now.
+func revisionTopics(topics []entities.Topic) []entities.NewsRevisionTopic {
+	snapshot := make([]entities.NewsRevisionTopic, len(topics))
+	for i, topic := range topics {
+		snapshot[i] = entities.NewsRevisionTopic{
+			Id:    topic.Id,
+			UUID:  topic.UUID,
+			Title: topic.Title,
+			Value: topic.Value,
+		}
+	}
+
+	return snapshot
+}
+
+// liveTopics looks up the topics recorded in a revision or pending edit,
+// leaving out those deleted since.
+func liveTopics(ctx context.Context, repos *repositories.Repositories, topics []entities.NewsRevisionTopic) ([]entities.Topic, error) {
+	topicEntities := []entities.Topic{}
+	for _, topic := range topics {
+		topicEntity, err := repos.Topic.GetByUuid(ctx, topic.UUID)
+		if isTopicNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		topicEntities = append(topicEntities, *topicEntity)
+	}
+
+	return topicEntities, nil
+}
+
+func newsRevisionResponse(revision *entities.NewsRevision) *response.NewsRevisionResponse {
+	topics := make([]response.TopicResponse, len(revision.Topics))
+	for i, topic := range revision.Topics {
+		topics[i] = response.TopicResponse{
+			Id:    topic.Id,
+			UUID:  topic.UUID,
+			Title: topic.Title,
+			Value: topic.Value,
+		}
+	}
+
+	return &response.NewsRevisionResponse{
+		UUID:      revision.UUID,
+		Revision:  revision.Revision,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Status:    string(revision.Status),
+		Language:  string(revision.Language),
+		Topics:    topics,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+// revisionTopicsMissing returns the topics of a that are not in b.
+func revisionTopicsMissing(a, b []entities.NewsRevisionTopic) []response.TopicResponse {
+	inB := map[string]bool{}
+	for _, topic := range b {
+		inB[topic.UUID] = true
+	}
+
+	missing := []response.TopicResponse{}
+	for _, topic := range a {
+		if !inB[topic.UUID] {
+			missing = append(missing, response.TopicResponse{
+				Id:    topic.Id,
+				UUID:  topic.UUID,
+				Title: topic.Title,
+				Value: topic.Value,
+			})
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+func (b backend) revisionUseCase() NewsRevisionUseCase {
+	return NewNewsRevisionUseCase(b.repos.News, b.repos.NewsRevision, b.repos.UnitOfWork, validator.New())
+}
+
+func topicValues(topics []response.TopicResponse) []string {
+	values := []string{}
+	for _, topic := range topics {
+		values = append(values, topic.Value)
+	}
+	return values
+}
+
+func TestDiffRevisions(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		topics := b.topicUseCase()
+		sport := createTopic(t, topics, "Sport", "sport")
+		politics := createTopic(t, topics, "Politics", "politics")
+
+		created, err := b.newsUseCase().CreateNews(ctx, dtos.CreateNewsRequest{
+			Title:   "Final tonight",
+			Content: "Kick off at eight.\nTickets are sold out.\n",
+			Status:  "draft",
+			Topics:  []dtos.TopicUuid{{Uuid: sport.UUID}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b.lockNews(t, created.UUID)
+		_, err = b.newsUseCase().UpdateByUuid(ctx, created.UUID, created.Version, dtos.UpdateNewsRequest{
+			Title:   "Final postponed",
+			Content: "Kick off moved to nine.\nTickets are sold out.\n",
+			Topics:  []dtos.TopicUuid{{Uuid: politics.UUID}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines, err := b.revisionUseCase().DiffRevisions(ctx, created.UUID, dtos.DiffNewsRevisionsRequest{From: 1, To: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantContent := []common.DiffChunk{
+			{Op: common.DiffDelete, Text: "Kick off at eight.\n"},
+			{Op: common.DiffInsert, Text: "Kick off moved to nine.\n"},
+			{Op: common.DiffEqual, Text: "Tickets are sold out.\n"},
+		}
+		if lines.Mode != "line" || !equalChunks(lines.Content, wantContent) {
+			t.Errorf("line diff of the content is %+v in mode %s, want %+v", lines.Content, lines.Mode, wantContent)
+		}
+		if added, removed := topicValues(lines.TopicsAdded), topicValues(lines.TopicsRemoved); len(added) != 1 || added[0] != "politics" || len(removed) != 1 || removed[0] != "sport" {
+			t.Errorf("topics added %v and removed %v, want politics added and sport removed", added, removed)
+		}
+
+		words, err := b.revisionUseCase().DiffRevisions(ctx, created.UUID, dtos.DiffNewsRevisionsRequest{From: 1, To: 2, Mode: "word"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantTitle := []common.DiffChunk{
+			{Op: common.DiffEqual, Text: "Final "},
+			{Op: common.DiffDelete, Text: "tonight"},
+			{Op: common.DiffInsert, Text: "postponed"},
+		}
+		if !equalChunks(words.Title, wantTitle) {
+			t.Errorf("word diff of the title is %+v, want %+v", words.Title, wantTitle)
+		}
+
+		if _, err := b.revisionUseCase().DiffRevisions(ctx, created.UUID, dtos.DiffNewsRevisionsRequest{From: 1, To: 3}); err == nil {
+			t.Error("diffed against a revision that does not exist")
+		}
+	})
+}
+
+func TestRestoreRevisionDropsDeletedTopics(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		topics := b.topicUseCase()
+		sport := createTopic(t, topics, "Sport", "sport")
+		football := createTopic(t, topics, "Football", "football")
+		politics := createTopic(t, topics, "Politics", "politics")
+		created := createNews(t, b.newsUseCase(), "Final tonight", sport, football)
+
+		b.lockNews(t, created.UUID)
+		updated, err := b.newsUseCase().UpdateByUuid(ctx, created.UUID, created.Version, dtos.UpdateNewsRequest{
+			Title:  "Elections",
+			Topics: []dtos.TopicUuid{{Uuid: politics.UUID}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := topics.DeleteByUuid(ctx, football.UUID, football.Version); err != nil {
+			t.Fatal(err)
+		}
+
+		restored, err := b.revisionUseCase().RestoreRevision(ctx, created.UUID, 1, updated.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.Title != "Final tonight" || restored.Status != "draft" {
+			t.Errorf("restored %q as %s, want Final tonight as draft", restored.Title, restored.Status)
+		}
+
+		got, err := b.newsUseCase().GetByUuid(ctx, created.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values := topicValues(got.Topics); len(values) != 1 || values[0] != "sport" {
+			t.Errorf("restored topics are %v, want sport only", values)
+		}
+
+		revisions, total, err := b.revisionUseCase().GetRevisions(ctx, created.UUID, firstPage())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 {
+			t.Fatalf("%d revisions, want the restore recorded as the third", total)
+		}
+		for _, revision := range revisions {
+			if revision.Revision == 3 && revision.Title != "Final tonight" {
+				t.Errorf("revision 3 is %q, want the restored title", revision.Title)
+			}
+		}
+	})
+}
+
+func equalChunks(got, want []common.DiffChunk) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+	"news-topic-api/common"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsRevisionUseCase interface {
+	GetRevisions(ctx context.Context, newsUuid string, pagination *common.Pagination) (revisions []*response.NewsRevisionResponse, totalItems int, err error)
+	GetRevision(ctx context.Context, newsUuid string, revision int) (*response.NewsRevisionResponse, error)
+	DiffRevisions(ctx context.Context, newsUuid string, dto dtos.DiffNewsRevisionsRequest) (*response.NewsRevisionDiffResponse, error)
+	// RestoreRevision fails with common.ErrStaleVersion when version is not
+	// the current version of the news.
+	RestoreRevision(ctx context.Context, newsUuid string, revision int, version int) (*response.NewsResponse, error)
+}
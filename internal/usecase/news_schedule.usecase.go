@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+var ErrPublishAtNotInFuture = errors.New("publish_at must be in the future")
+
+// ErrNewsNotScheduled is returned when rescheduling news that has no
+// schedule to move.
+var ErrNewsNotScheduled = errors.New("news is not scheduled")
+
+type newsScheduleUseCase struct {
+	newsRepo repositories.NewsRepository
+	uow      repositories.UnitOfWork
+	validate *validator.Validate
+}
+
+func NewNewsScheduleUseCase(newsRepo repositories.NewsRepository, uow repositories.UnitOfWork, validate *validator.Validate) NewsScheduleUseCase {
+	return &newsScheduleUseCase{
+		newsRepo: newsRepo,
+		uow:      uow,
+		validate: validate,
+	}
+}
+
+func (uc *newsScheduleUseCase) ScheduleNews(ctx context.Context, uuid string, version int, dto dtos.ScheduleNewsRequest) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	publishAt, err := futurePublishAt(*dto.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.changeSchedule(ctx, uuid, version, entities.NewsStatusScheduled, &publishAt, entities.AuditActionStatusChange)
+}
+
+func (uc *newsScheduleUseCase) RescheduleNews(ctx context.Context, uuid string, version int, dto dtos.ScheduleNewsRequest) (*response.NewsResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	publishAt, err := futurePublishAt(*dto.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.changeSchedule(ctx, uuid, version, entities.NewsStatusScheduled, &publishAt, entities.AuditActionUpdate)
+}
+
+// CancelSchedule takes news off the schedule. It goes back to approved, so
+// it can be published right away or scheduled again without a new review.
+func (uc *newsScheduleUseCase) CancelSchedule(ctx context.Context, uuid string, version int) (*response.NewsResponse, error) {
+	return uc.changeSchedule(ctx, uuid, version, entities.NewsStatusApproved, nil, entities.AuditActionStatusChange)
+}
+
+// changeSchedule moves news to status to with the given publish time,
+// recording a revision and an audit event. An update keeps the status and
+// only moves the publish time of scheduled news.
+func (uc *newsScheduleUseCase) changeSchedule(ctx context.Context, uuid string, version int, to entities.StatusType, publishAt *time.Time, action entities.AuditAction) (*response.NewsResponse, error) {
+	var changed *entities.News
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existing, err := repos.News.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEmbargo(ctx, existing, time.Now()); err != nil {
+			return err
+		}
+
+		if existing.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		if err := repos.News.LoadTopics(ctx, existing); err != nil {
+			return err
+		}
+		before := toNewsResponse(existing)
+
+		if action == entities.AuditActionUpdate {
+			if existing.Status != entities.NewsStatusScheduled {
+				return ErrNewsNotScheduled
+			}
+		} else {
+			tc, err := transitionContext(ctx, repos.NewsReviewComment, existing, time.Now())
+			if err != nil {
+				return err
+			}
+
+			existing.PublishAt = publishAt
+			if err := existing.CheckTransition(to, tc); err != nil {
+				return err
+			}
+		}
+
+		if publishAt != nil && existing.ExpiresAt != nil && !existing.ExpiresAt.After(*publishAt) {
+			return ErrExpiresBeforePublish
+		}
+
+		changed, err = repos.News.UpdateSchedule(ctx, uuid, version, to, publishAt)
+		if err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, repos, changed); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, action, entities.AuditEntityNews, uuid, before, toNewsResponse(changed))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNewsResponse(changed), nil
+}
+
+func (uc *newsScheduleUseCase) PublishDue(ctx context.Context, now time.Time, limit int) (published int, err error) {
+	now = now.UTC()
+
+	due, err := uc.newsRepo.GetDueScheduled(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	for _, news := range due {
+		err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+			tc, err := transitionContext(ctx, repos.NewsReviewComment, news, now)
+			if err != nil {
+				return err
+			}
+
+			if err := news.CheckTransition(entities.NewsStatusPublished, tc); err != nil {
+				return err
+			}
+
+			if err := repos.News.LoadTopics(ctx, news); err != nil {
+				return err
+			}
+			before := toNewsResponse(news)
+
+			publishedNews, err := repos.News.PublishScheduled(ctx, news.UUID, now)
+			if err != nil {
+				return err
+			}
+
+			if err := recordRevision(ctx, repos, publishedNews); err != nil {
+				return err
+			}
+
+			return recordAudit(ctx, repos, entities.AuditActionStatusChange, entities.AuditEntityNews, news.UUID, before, toNewsResponse(publishedNews))
+		})
+
+		if errors.Is(err, common.ErrStaleVersion) {
+			// published, rescheduled or cancelled since it was listed
+			continue
+		} else if err != nil {
+			// one failing item must not hold back the rest of the batch
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		published++
+	}
+
+	return published, firstErr
+}
+
+// futurePublishAt checks that a publish time lies ahead and stores it in
+// UTC, which keeps the text comparison SQLite does on it correct.
+func futurePublishAt(publishAt time.Time) (time.Time, error) {
+	if !publishAt.After(time.Now()) {
+		return time.Time{}, ErrPublishAtNotInFuture
+	}
+
+	return publishAt.UTC(), nil
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// listedTogether holds every publisher after it lists the due news until
+// all of them have, so they all try to publish the same items.
+type listedTogether struct {
+	repositories.NewsRepository
+	listed *sync.WaitGroup
+}
+
+func (r listedTogether) GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]*entities.News, error) {
+	due, err := r.NewsRepository.GetDueScheduled(ctx, now, limit)
+	r.listed.Done()
+	r.listed.Wait()
+	return due, err
+}
+
+func TestPublishDueNeverPublishesTwice(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		created := createNews(t, news, "Final tonight", createTopic(t, b.topicUseCase(), "Sport", "sport"))
+
+		current := created
+		for _, status := range []string{"in_review", "approved"} {
+			var err error
+			current, err = news.UpdateNewsStatus(ctx, created.UUID, current.Version, dtos.UpdateNewsStatus{Status: status})
+			if err != nil {
+				t.Fatalf("move to %s: %v", status, err)
+			}
+		}
+
+		publishAt := time.Now().Add(time.Hour)
+		schedule := NewNewsScheduleUseCase(b.repos.News, b.repos.UnitOfWork, validator.New())
+		if _, err := schedule.ScheduleNews(ctx, created.UUID, current.Version, dtos.ScheduleNewsRequest{PublishAt: &publishAt}); err != nil {
+			t.Fatal(err)
+		}
+
+		// publishers on several replicas wake up at the same time
+		const publishers = 8
+		due := publishAt.Add(time.Minute)
+		results := make(chan int, publishers)
+		var listed, wg sync.WaitGroup
+		listed.Add(publishers)
+		for i := 0; i < publishers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				replica := NewNewsScheduleUseCase(listedTogether{b.repos.News, &listed}, b.repos.UnitOfWork, validator.New())
+				published, err := replica.PublishDue(ctx, due, 10)
+				if err != nil {
+					t.Error(err)
+				}
+				results <- published
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		total := 0
+		for published := range results {
+			total += published
+		}
+		if total != 1 {
+			t.Errorf("published %d times, want once", total)
+		}
+
+		if published, err := schedule.PublishDue(ctx, due.Add(time.Hour), 10); err != nil || published != 0 {
+			t.Errorf("publishing again: published %d, err %v", published, err)
+		}
+
+		got, err := news.GetByUuid(ctx, created.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != string(entities.NewsStatusPublished) {
+			t.Errorf("status is %s, want published", got.Status)
+		}
+
+		revisions, _, err := b.repos.NewsRevision.GetRevisions(ctx, created.Id, &common.Pagination{Limit: 100, Page: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		publishedRevisions := 0
+		for _, revision := range revisions {
+			if revision.Status == entities.NewsStatusPublished {
+				publishedRevisions++
+			}
+		}
+		if publishedRevisions != 1 {
+			t.Errorf("%d published revisions, want 1", publishedRevisions)
+		}
+	})
+}
+
+func TestPublishDueSkipsNewsWithOpenThreads(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		schedule := NewNewsScheduleUseCase(b.repos.News, b.repos.UnitOfWork, validator.New())
+		comments := NewNewsReviewCommentUseCase(b.repos.News, b.repos.NewsReviewComment, validator.New())
+
+		// blocked is due first, so it would head every batch
+		publishAt := time.Now().Add(time.Hour)
+		scheduled := map[string]*response.NewsResponse{}
+		for i, title := range []string{"Blocked", "Ready"} {
+			current := createNews(t, news, title)
+			for _, status := range []string{"in_review", "approved"} {
+				var err error
+				current, err = news.UpdateNewsStatus(ctx, current.UUID, current.Version, dtos.UpdateNewsStatus{Status: status})
+				if err != nil {
+					t.Fatalf("move %s to %s: %v", title, status, err)
+				}
+			}
+			at := publishAt.Add(time.Duration(i) * time.Minute)
+			current, err := schedule.ScheduleNews(ctx, current.UUID, current.Version, dtos.ScheduleNewsRequest{PublishAt: &at})
+			if err != nil {
+				t.Fatal(err)
+			}
+			scheduled[title] = current
+		}
+		blocked, ready := scheduled["Blocked"], scheduled["Ready"]
+
+		if _, err := comments.CreateComment(ctx, blocked.UUID, dtos.CreateNewsReviewCommentRequest{Body: "Source?"}); !errors.Is(err, ErrNewsScheduledThread) {
+			t.Fatalf("opening a thread on scheduled news: got %v, want ErrNewsScheduledThread", err)
+		}
+
+		// a thread opened while the news was being scheduled still gets
+		// through, straight to the repository
+		thread, err := b.repos.NewsReviewComment.CreateComment(ctx, &entities.NewsReviewComment{NewsId: blocked.Id, Author: "tester", Body: "Source?"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := comments.ResolveComment(ctx, blocked.UUID, thread.UUID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := comments.ReopenComment(ctx, blocked.UUID, thread.UUID); !errors.Is(err, ErrNewsScheduledThread) {
+			t.Fatalf("reopening a thread on scheduled news: got %v, want ErrNewsScheduledThread", err)
+		}
+		if _, err := b.repos.NewsReviewComment.SetResolved(ctx, thread, nil, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		due := publishAt.Add(time.Hour)
+		for tick := 0; tick < 2; tick++ {
+			published, err := schedule.PublishDue(ctx, due, 1)
+			if err != nil {
+				t.Fatalf("tick %d: %v", tick, err)
+			}
+			if want := 1 - tick; published != want {
+				t.Fatalf("tick %d: published %d, want %d", tick, published, want)
+			}
+		}
+
+		for uuid, want := range map[string]entities.StatusType{blocked.UUID: entities.NewsStatusScheduled, ready.UUID: entities.NewsStatusPublished} {
+			got, err := news.GetByUuid(ctx, uuid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != string(want) {
+				t.Errorf("%s is %s, want %s", got.Title, got.Status, want)
+			}
+		}
+	})
+}
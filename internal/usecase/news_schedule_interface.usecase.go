@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type NewsScheduleUseCase interface {
+	// ScheduleNews, RescheduleNews and CancelSchedule take the version the
+	// client last read and fail with common.ErrStaleVersion when the news
+	// was changed since.
+	ScheduleNews(ctx context.Context, uuid string, version int, dto dtos.ScheduleNewsRequest) (*response.NewsResponse, error)
+	RescheduleNews(ctx context.Context, uuid string, version int, dto dtos.ScheduleNewsRequest) (*response.NewsResponse, error)
+	// CancelSchedule takes news off the schedule and back to approved.
+	CancelSchedule(ctx context.Context, uuid string, version int) (*response.NewsResponse, error)
+
+	// PublishDue publishes up to limit scheduled news that are due at now
+	// and returns how many it published. Items claimed by another publisher
+	// in the meantime are skipped.
+	PublishDue(ctx context.Context, now time.Time, limit int) (published int, err error)
+}
@@ -1,10 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"news-topic-api/common"
 
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 
 	"news-topic-api/internal/delivery/data/dtos"
 	response "news-topic-api/internal/delivery/data/responses"
@@ -12,20 +15,51 @@ import (
 	"news-topic-api/internal/repositories"
 )
 
+// ErrParentTopicNotFound is returned when the parent named for a topic
+// does not exist or is deleted.
+var ErrParentTopicNotFound = errors.New("parent topic not found")
+
+// ErrTopicCycle is returned when a topic would be moved under itself or
+// one of its descendants.
+var ErrTopicCycle = errors.New("a topic cannot be moved under itself or its descendants")
+
+// ErrTopicHasChildren is returned when deleting a topic that still has
+// live child topics.
+var ErrTopicHasChildren = errors.New("topic has child topics, move or delete them first")
+
+// isTopicNotFound tells a topic lookup that found nothing apart from one
+// that failed.
+func isTopicNotFound(err error) bool {
+	return err != nil && (errors.Is(err, gorm.ErrRecordNotFound) || err.Error() == "topic not found")
+}
+
 type topicUseCase struct {
 	topicRepo repositories.TopicRepository
+	aliasRepo repositories.TopicAliasRepository
+	uow       repositories.UnitOfWork
 	validate  *validator.Validate
 }
 
-func NewTopicUseCase(topicRepo repositories.TopicRepository, validate *validator.Validate) TopicUseCase {
+func NewTopicUseCase(topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, uow repositories.UnitOfWork, validate *validator.Validate) TopicUseCase {
 	return &topicUseCase{
 		topicRepo,
+		aliasRepo,
+		uow,
 		validate,
 	}
 }
 
-func (uc *topicUseCase) GetAllTopics(pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error) {
-	topicModel, totalItems64, err := uc.topicRepo.GetTopics(pagination)
+func (uc *topicUseCase) GetAllTopics(ctx context.Context, pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error) {
+	if pagination.Keyset && pagination.Limit < 1 {
+		return nil, 0, fmt.Errorf("%w: per_page must be at least 1", common.ErrInvalidPagination)
+	}
+
+	topicModel, totalItems64, err := uc.topicRepo.GetTopics(ctx, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	index, err := loadTopicAncestry(ctx, uc.topicRepo, topicModel...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -33,34 +67,58 @@ func (uc *topicUseCase) GetAllTopics(pagination *common.Pagination) (topics []*r
 	totalItems = int(totalItems64)
 
 	for _, topic := range topicModel {
-		topics = append(topics, &response.TopicResponse{
-			Id:    uint(topic.Id),
-			UUID:  topic.UUID,
-			Title: topic.Title,
-			Value: topic.Value,
-		})
+		topics = append(topics, index.response(topic))
 	}
 
 	return topics, totalItems, nil
 }
 
-func (uc *topicUseCase) GetByUuid(uuid string) (topic *response.TopicResponse, err error) {
-	topicModel, err := uc.topicRepo.GetByUuid(uuid)
+func (uc *topicUseCase) GetByUuid(ctx context.Context, uuid string) (topic *response.TopicResponse, err error) {
+	topicModel, err := uc.topicRepo.GetByUuid(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	topic = &response.TopicResponse{
-		Id:    uint(topicModel.Id),
-		UUID:  topicModel.UUID,
-		Title: topicModel.Title,
-		Value: topicModel.Value,
+	index, err := loadTopicAncestry(ctx, uc.topicRepo, topicModel)
+	if err != nil {
+		return nil, err
 	}
 
-	return topic, nil
+	return index.response(topicModel), nil
 }
 
-func (uc *topicUseCase) CreateTopic(topicDto dtos.CreateTopicRequest) (*response.TopicResponse, error) {
+// LookupByValue finds the topic a value names, either as its own value or
+// as one of its aliases.
+func (uc *topicUseCase) LookupByValue(ctx context.Context, value string) (*response.TopicResponse, error) {
+	topic, err := liveTopicByValue(ctx, uc.topicRepo, uc.aliasRepo, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if topic == nil {
+		return nil, errors.New("topic not found")
+	}
+
+	index, err := loadTopicAncestry(ctx, uc.topicRepo, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.response(topic), nil
+}
+
+// GetTree returns every topic nested under its parent, each level ordered
+// by title.
+func (uc *topicUseCase) GetTree(ctx context.Context) ([]*response.TopicTreeResponse, error) {
+	index, topics, err := loadTopicIndex(ctx, uc.topicRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.tree(topics), nil
+}
+
+func (uc *topicUseCase) CreateTopic(ctx context.Context, topicDto dtos.CreateTopicRequest) (*response.TopicResponse, error) {
 	if err := uc.validate.Struct(&topicDto); err != nil {
 		return nil, err
 	}
@@ -73,53 +131,259 @@ func (uc *topicUseCase) CreateTopic(topicDto dtos.CreateTopicRequest) (*response
 		return nil, errors.New("topic value cannot be empty")
 	}
 
-	createTopic, err := uc.topicRepo.CreateTopic(
-		&entities.Topic{
-			Title: topicDto.Title,
-			Value: topicDto.Value,
-		},
-	)
+	var topicRes *response.TopicResponse
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		alias, err := repos.TopicAlias.GetByValue(ctx, topicDto.Value)
+		if err != nil {
+			return err
+		}
+		if alias != nil {
+			return ErrTopicValueIsAlias
+		}
+
+		var parentId *uint
+		if topicDto.ParentUuid != "" {
+			parent, err := repos.Topic.GetByUuid(ctx, topicDto.ParentUuid)
+			if isTopicNotFound(err) {
+				return ErrParentTopicNotFound
+			} else if err != nil {
+				return err
+			}
+			parentId = &parent.Id
+		}
+
+		createTopic, err := repos.Topic.CreateTopic(
+			ctx,
+			&entities.Topic{
+				Title:    topicDto.Title,
+				Value:    topicDto.Value,
+				ParentId: parentId,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		index, err := loadTopicAncestry(ctx, repos.Topic, createTopic)
+		if err != nil {
+			return err
+		}
+		topicRes = index.response(createTopic)
+
+		return recordAudit(ctx, repos, entities.AuditActionCreate, entities.AuditEntityTopic, createTopic.UUID, nil, topicRes)
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	topicRes := &response.TopicResponse{
-		Id:    createTopic.Id,
-		UUID:  createTopic.UUID,
-		Title: createTopic.Title,
-		Value: createTopic.Value,
-	}
-
 	return topicRes, nil
 }
 
-func (uc *topicUseCase) UpdateByUuid(uuid string, topicDto dtos.UpdateTopicRequest) (*response.TopicResponse, error) {
+func (uc *topicUseCase) UpdateByUuid(ctx context.Context, uuid string, version int, topicDto dtos.UpdateTopicRequest) (*response.TopicResponse, error) {
 	if err := uc.validate.Struct(&topicDto); err != nil {
 		return nil, err
 	}
 
-	topicRes, topicErr := uc.topicRepo.UpdateByUuid(
-		uuid,
-		&entities.Topic{
-			Title: topicDto.Title,
-		},
-	)
+	var topicResponse *response.TopicResponse
+
+	topicErr := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		existingTopic, err := repos.Topic.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		index, err := loadTopicAncestry(ctx, repos.Topic, existingTopic)
+		if err != nil {
+			return err
+		}
+		before := index.response(existingTopic)
+
+		parentId := existingTopic.ParentId
+		if topicDto.ParentUuid != nil {
+			parentId, err = uc.newParent(ctx, repos, existingTopic, *topicDto.ParentUuid)
+			if err != nil {
+				return err
+			}
+		}
+
+		topicRes, err := repos.Topic.UpdateByUuid(
+			ctx,
+			uuid,
+			&entities.Topic{
+				Title:   topicDto.Title,
+				Version: version,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if topicDto.ParentUuid != nil {
+			if err := repos.Topic.SetParent(ctx, topicRes.Id, parentId); err != nil {
+				return err
+			}
+			topicRes.ParentId = parentId
+		}
+
+		index, err = loadTopicAncestry(ctx, repos.Topic, topicRes)
+		if err != nil {
+			return err
+		}
+		topicResponse = index.response(topicRes)
+
+		return recordAudit(ctx, repos, entities.AuditActionUpdate, entities.AuditEntityTopic, uuid, before, topicResponse)
+	})
 
 	if topicErr != nil {
 		return nil, topicErr
 	}
 
-	topicResponse := &response.TopicResponse{
-		Id:    topicRes.Id,
-		UUID:  topicRes.UUID,
-		Title: topicRes.Title,
-		Value: topicRes.Value,
+	return topicResponse, nil
+}
+
+// newParent resolves the parent a topic is moved under, nil for the root,
+// refusing moves that would make the hierarchy loop.
+func (uc *topicUseCase) newParent(ctx context.Context, repos *repositories.Repositories, topic *entities.Topic, parentUuid string) (*uint, error) {
+	if parentUuid == "" {
+		return nil, nil
+	}
+
+	parent, err := repos.Topic.GetByUuid(ctx, parentUuid)
+	if isTopicNotFound(err) {
+		return nil, ErrParentTopicNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if parent.Id == topic.Id {
+		return nil, ErrTopicCycle
+	}
+
+	index, err := loadTopicAncestry(ctx, repos.Topic, parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range index.ancestors(parent) {
+		if ancestor.Id == topic.Id {
+			return nil, ErrTopicCycle
+		}
+	}
+
+	return &parent.Id, nil
+}
+
+// DeleteByUuid moves the topic to the trash. Its news links are set aside
+// so restoring the topic can attach it to the same news again.
+func (uc *topicUseCase) DeleteByUuid(ctx context.Context, uuid string, version int) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		topic, err := repos.Topic.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if topic.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		children, err := repos.Topic.CountChildren(ctx, topic.Id)
+		if err != nil {
+			return err
+		}
+		if children > 0 {
+			return ErrTopicHasChildren
+		}
+
+		if err := repos.Topic.TrashNewsLinks(ctx, topic.Id); err != nil {
+			return err
+		}
+
+		if err := repos.Topic.DeleteByUuid(ctx, uuid, version); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionDelete, entities.AuditEntityTopic, uuid, toTopicResponse(topic), nil)
+	})
+}
+
+func (uc *topicUseCase) GetTrashedTopics(ctx context.Context, pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error) {
+	topicModel, totalItems64, err := uc.topicRepo.GetTrashed(ctx, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	topics = []*response.TopicResponse{}
+	for _, topic := range topicModel {
+		deletedAt := topic.DeletedAt.Time
+		topics = append(topics, &response.TopicResponse{
+			Id:        topic.Id,
+			UUID:      topic.UUID,
+			Title:     topic.Title,
+			Value:     topic.Value,
+			Version:   topic.Version,
+			DeletedAt: &deletedAt,
+		})
+	}
+
+	return topics, int(totalItems64), nil
+}
+
+func (uc *topicUseCase) RestoreByUuid(ctx context.Context, uuid string) (*response.TopicResponse, error) {
+	var topicResponse *response.TopicResponse
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		restoredTopic, err := repos.Topic.RestoreByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Topic.RestoreNewsLinks(ctx, restoredTopic.Id); err != nil {
+			return err
+		}
+
+		index, err := loadTopicAncestry(ctx, repos.Topic, restoredTopic)
+		if err != nil {
+			return err
+		}
+
+		// a parent deleted in the meantime cannot hold the topic, so it
+		// comes back at the root
+		if restoredTopic.ParentId != nil && index[*restoredTopic.ParentId] == nil {
+			if err := repos.Topic.SetParent(ctx, restoredTopic.Id, nil); err != nil {
+				return err
+			}
+			restoredTopic.ParentId = nil
+		}
+
+		index[restoredTopic.Id] = restoredTopic
+		topicResponse = index.response(restoredTopic)
+
+		return recordAudit(ctx, repos, entities.AuditActionRestore, entities.AuditEntityTopic, uuid, nil, topicResponse)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return topicResponse, nil
 }
 
-func (uc *topicUseCase) DeleteByUuid(uuid string) error {
-	return uc.topicRepo.DeleteByUuid(uuid)
+func (uc *topicUseCase) PurgeByUuid(ctx context.Context, uuid string) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		if err := repos.Topic.PurgeByUuid(ctx, uuid); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionPurge, entities.AuditEntityTopic, uuid, nil, nil)
+	})
+}
+
+func toTopicResponse(topic *entities.Topic) *response.TopicResponse {
+	return &response.TopicResponse{
+		Id:      topic.Id,
+		UUID:    topic.UUID,
+		Title:   topic.Title,
+		Value:   topic.Value,
+		Version: topic.Version,
+	}
 }
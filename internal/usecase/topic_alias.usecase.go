@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// ErrAliasTaken is returned when an alias value is already the value of a
+// topic, deleted ones included, or of another alias.
+var ErrAliasTaken = errors.New("value is already used by a topic or another alias")
+
+// ErrTopicValueIsAlias is returned when a new topic takes a value that is
+// already an alias.
+var ErrTopicValueIsAlias = errors.New("value is already an alias of a topic")
+
+type topicAliasUseCase struct {
+	topicRepo repositories.TopicRepository
+	aliasRepo repositories.TopicAliasRepository
+	uow       repositories.UnitOfWork
+	validate  *validator.Validate
+}
+
+func NewTopicAliasUseCase(topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, uow repositories.UnitOfWork, validate *validator.Validate) TopicAliasUseCase {
+	return &topicAliasUseCase{
+		topicRepo: topicRepo,
+		aliasRepo: aliasRepo,
+		uow:       uow,
+		validate:  validate,
+	}
+}
+
+func (uc *topicAliasUseCase) GetAliases(ctx context.Context, topicUuid string) ([]*response.TopicAliasResponse, error) {
+	topic, err := uc.topicRepo.GetByUuid(ctx, topicUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := uc.aliasRepo.GetAliases(ctx, topic.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasResponses := []*response.TopicAliasResponse{}
+	for _, alias := range aliases {
+		aliasResponses = append(aliasResponses, toTopicAliasResponse(alias))
+	}
+
+	return aliasResponses, nil
+}
+
+func (uc *topicAliasUseCase) CreateAlias(ctx context.Context, topicUuid string, dto dtos.TopicAliasRequest) (*response.TopicAliasResponse, error) {
+	dto.Value = strings.TrimSpace(dto.Value)
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	var aliasResponse *response.TopicAliasResponse
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		topic, err := repos.Topic.GetByUuid(ctx, topicUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAliasValue(ctx, repos, 0, dto.Value); err != nil {
+			return err
+		}
+
+		alias, err := repos.TopicAlias.CreateAlias(ctx, &entities.TopicAlias{TopicId: topic.Id, Value: dto.Value})
+		if err != nil {
+			return err
+		}
+		aliasResponse = toTopicAliasResponse(alias)
+
+		return recordAudit(ctx, repos, entities.AuditActionAliasesChange, entities.AuditEntityTopic, topicUuid, nil, aliasResponse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return aliasResponse, nil
+}
+
+func (uc *topicAliasUseCase) UpdateAlias(ctx context.Context, topicUuid string, aliasUuid string, dto dtos.TopicAliasRequest) (*response.TopicAliasResponse, error) {
+	dto.Value = strings.TrimSpace(dto.Value)
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	var aliasResponse *response.TopicAliasResponse
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		alias, err := topicAlias(ctx, repos, topicUuid, aliasUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAliasValue(ctx, repos, alias.Id, dto.Value); err != nil {
+			return err
+		}
+
+		updated, err := repos.TopicAlias.UpdateValue(ctx, alias.Id, dto.Value)
+		if err != nil {
+			return err
+		}
+		aliasResponse = toTopicAliasResponse(updated)
+
+		return recordAudit(ctx, repos, entities.AuditActionAliasesChange, entities.AuditEntityTopic, topicUuid, toTopicAliasResponse(alias), aliasResponse)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return aliasResponse, nil
+}
+
+func (uc *topicAliasUseCase) DeleteAlias(ctx context.Context, topicUuid string, aliasUuid string) error {
+	return uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		alias, err := topicAlias(ctx, repos, topicUuid, aliasUuid)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.TopicAlias.DeleteByUuid(ctx, aliasUuid); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionAliasesChange, entities.AuditEntityTopic, topicUuid, toTopicAliasResponse(alias), nil)
+	})
+}
+
+// topicAlias returns an alias of the topic, treating an alias of another
+// topic as missing.
+func topicAlias(ctx context.Context, repos *repositories.Repositories, topicUuid string, aliasUuid string) (*entities.TopicAlias, error) {
+	topic, err := repos.Topic.GetByUuid(ctx, topicUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := repos.TopicAlias.GetByUuid(ctx, aliasUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if alias.TopicId != topic.Id {
+		return nil, errors.New("topic alias not found")
+	}
+
+	return alias, nil
+}
+
+// checkAliasValue keeps alias values apart from topic values, so a value
+// in the news filter names exactly one topic. aliasId is the alias being
+// renamed, zero for a new one.
+func checkAliasValue(ctx context.Context, repos *repositories.Repositories, aliasId uint, value string) error {
+	topic, err := repos.Topic.GetByValue(ctx, value)
+	if err != nil {
+		return err
+	}
+	if topic != nil {
+		return ErrAliasTaken
+	}
+
+	alias, err := repos.TopicAlias.GetByValue(ctx, value)
+	if err != nil {
+		return err
+	}
+	if alias != nil && alias.Id != aliasId {
+		return ErrAliasTaken
+	}
+
+	return nil
+}
+
+func toTopicAliasResponse(alias *entities.TopicAlias) *response.TopicAliasResponse {
+	return &response.TopicAliasResponse{
+		UUID:      alias.UUID,
+		Value:     alias.Value,
+		CreatedAt: alias.CreatedAt,
+	}
+}
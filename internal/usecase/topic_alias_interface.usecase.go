@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type TopicAliasUseCase interface {
+	GetAliases(ctx context.Context, topicUuid string) ([]*response.TopicAliasResponse, error)
+	CreateAlias(ctx context.Context, topicUuid string, dto dtos.TopicAliasRequest) (*response.TopicAliasResponse, error)
+	UpdateAlias(ctx context.Context, topicUuid string, aliasUuid string, dto dtos.TopicAliasRequest) (*response.TopicAliasResponse, error)
+	DeleteAlias(ctx context.Context, topicUuid string, aliasUuid string) error
+}
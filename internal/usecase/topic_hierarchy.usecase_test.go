@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+func createChildTopic(t *testing.T, uc TopicUseCase, title, value string, parent *response.TopicResponse) *response.TopicResponse {
+	t.Helper()
+
+	topic, err := uc.CreateTopic(testContext(), dtos.CreateTopicRequest{Title: title, Value: value, ParentUuid: parent.UUID})
+	if err != nil {
+		t.Fatalf("create topic %s: %v", value, err)
+	}
+	return topic
+}
+
+func pathValues(path []response.TopicPathResponse) []string {
+	values := []string{}
+	for _, step := range path {
+		values = append(values, step.Value)
+	}
+	return values
+}
+
+func TestTopicHierarchy(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		topics := b.topicUseCase()
+		news := b.newsUseCase()
+
+		sport := createTopic(t, topics, "Sport", "sport")
+		football := createChildTopic(t, topics, "Football", "football", sport)
+		league := createChildTopic(t, topics, "Premier League", "premier-league", football)
+		politics := createTopic(t, topics, "Politics", "politics")
+
+		got, err := topics.GetByUuid(ctx, league.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path := pathValues(got.Path); len(path) != 3 || path[0] != "sport" || path[1] != "football" || path[2] != "premier-league" {
+			t.Errorf("breadcrumb is %v, want sport, football, premier-league", path)
+		}
+
+		// under itself, its child or its grandchild
+		for _, parent := range []*response.TopicResponse{sport, football, league} {
+			parentUuid := parent.UUID
+			_, err := topics.UpdateByUuid(ctx, sport.UUID, sport.Version, dtos.UpdateTopicRequest{ParentUuid: &parentUuid})
+			if !errors.Is(err, ErrTopicCycle) {
+				t.Errorf("moving sport under %s: got %v, want ErrTopicCycle", parent.Value, err)
+			}
+		}
+
+		createNews(t, news, "Season preview", sport)
+		createNews(t, news, "Transfer rumours", football)
+		createNews(t, news, "Final tonight", league)
+		createNews(t, news, "Elections", politics)
+
+		listed := func(value string, descendants bool) int {
+			t.Helper()
+
+			_, total, err := news.GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value, IncludeDescendants: descendants})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return total
+		}
+
+		for _, c := range []struct {
+			value       string
+			descendants bool
+			want        int
+		}{
+			{"sport", false, 1},
+			{"sport", true, 3},
+			{"football", true, 2},
+			{"premier-league", true, 1},
+			{"politics", true, 1},
+		} {
+			if got := listed(c.value, c.descendants); got != c.want {
+				t.Errorf("news under %s, descendants %t: got %d, want %d", c.value, c.descendants, got, c.want)
+			}
+		}
+
+		// moving football to the root takes its subtree along
+		root := ""
+		if _, err := topics.UpdateByUuid(ctx, football.UUID, football.Version, dtos.UpdateTopicRequest{ParentUuid: &root}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err = topics.GetByUuid(ctx, league.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path := pathValues(got.Path); len(path) != 2 || path[0] != "football" || path[1] != "premier-league" {
+			t.Errorf("breadcrumb after the move is %v, want football, premier-league", path)
+		}
+		if got := listed("sport", true); got != 1 {
+			t.Errorf("news under sport after the move: got %d, want 1", got)
+		}
+	})
+}
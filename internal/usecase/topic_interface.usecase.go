@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"news-topic-api/common"
 
 	"news-topic-api/internal/delivery/data/dtos"
@@ -8,9 +9,20 @@ import (
 )
 
 type TopicUseCase interface {
-	GetAllTopics(pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error)
-	GetByUuid(uuid string) (topic *response.TopicResponse, err error)
-	CreateTopic(topicDto dtos.CreateTopicRequest) (topicRes *response.TopicResponse, err error)
-	UpdateByUuid(uuid string, topicDto dtos.UpdateTopicRequest) (*response.TopicResponse, error)
-	DeleteByUuid(uuid string) error
+	GetAllTopics(ctx context.Context, pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error)
+	GetByUuid(ctx context.Context, uuid string) (topic *response.TopicResponse, err error)
+	GetTree(ctx context.Context) ([]*response.TopicTreeResponse, error)
+	LookupByValue(ctx context.Context, value string) (*response.TopicResponse, error)
+	CreateTopic(ctx context.Context, topicDto dtos.CreateTopicRequest) (topicRes *response.TopicResponse, err error)
+	// The writes below take the version the client last read and fail with
+	// common.ErrStaleVersion when the topic was changed since.
+	UpdateByUuid(ctx context.Context, uuid string, version int, topicDto dtos.UpdateTopicRequest) (*response.TopicResponse, error)
+	DeleteByUuid(ctx context.Context, uuid string, version int) error
+	// MergeTopic moves the news of the topic to the target topic and
+	// removes it, keeping its value as an alias of the target.
+	MergeTopic(ctx context.Context, uuid string, version int, dto dtos.MergeTopicRequest) (*response.TopicMergeResponse, error)
+
+	GetTrashedTopics(ctx context.Context, pagination *common.Pagination) (topics []*response.TopicResponse, totalItems int, err error)
+	RestoreByUuid(ctx context.Context, uuid string) (*response.TopicResponse, error)
+	PurgeByUuid(ctx context.Context, uuid string) error
 }
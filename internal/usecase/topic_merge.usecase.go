@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"news-topic-api/common"
+	"time"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// ErrMergeTargetNotFound is returned when the topic to merge into does not
+// exist or is deleted.
+var ErrMergeTargetNotFound = errors.New("merge target topic not found")
+
+var ErrMergeIntoItself = errors.New("a topic cannot be merged into itself")
+
+// ErrMergeIntoDescendant is returned when the target sits below the merged
+// topic, which would leave it as its own ancestor.
+var ErrMergeIntoDescendant = errors.New("a topic cannot be merged into one of its descendants")
+
+// MergeTopic folds a duplicate topic into the target in one unit of work.
+// Its news, pins and child topics move to the target, and its value stays
+// behind as an alias so filters on it keep working. The merged topic is
+// deleted for good rather than trashed, as there is nothing left to
+// restore.
+func (uc *topicUseCase) MergeTopic(ctx context.Context, uuid string, version int, dto dtos.MergeTopicRequest) (*response.TopicMergeResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	var merge *response.TopicMergeResponse
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		source, err := repos.Topic.GetByUuid(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if source.Version != version {
+			return common.ErrStaleVersion
+		}
+
+		target, err := repos.Topic.GetByUuid(ctx, dto.TargetUuid)
+		if err != nil {
+			return ErrMergeTargetNotFound
+		}
+
+		if target.Id == source.Id {
+			return ErrMergeIntoItself
+		}
+
+		index, err := loadTopicAncestry(ctx, repos.Topic, source, target)
+		if err != nil {
+			return err
+		}
+
+		for _, ancestor := range index.ancestors(target) {
+			if ancestor.Id == source.Id {
+				return ErrMergeIntoDescendant
+			}
+		}
+
+		before := index.response(source)
+
+		moved, err := repos.Topic.MergeNewsLinks(ctx, source.Id, target.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := mergePins(ctx, repos, source.Id, target.Id); err != nil {
+			return err
+		}
+
+		if err := repos.Topic.MoveChildren(ctx, source.Id, target.Id); err != nil {
+			return err
+		}
+
+		if err := repos.TopicAlias.MoveAliases(ctx, source.Id, target.Id); err != nil {
+			return err
+		}
+
+		if source.Value != "" {
+			_, err := repos.TopicAlias.CreateAlias(ctx, &entities.TopicAlias{TopicId: target.Id, Value: source.Value})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Topic.DeleteByUuid(ctx, uuid, version); err != nil {
+			return err
+		}
+
+		if err := repos.Topic.PurgeByUuid(ctx, uuid); err != nil {
+			return err
+		}
+
+		merge = &response.TopicMergeResponse{
+			Topic:     index.response(target),
+			NewsMoved: moved,
+			Alias:     source.Value,
+		}
+
+		return recordAudit(ctx, repos, entities.AuditActionMerge, entities.AuditEntityTopic, uuid, before, merge.Topic)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return merge, nil
+}
+
+// mergePins adds the active pins of one topic after those of another,
+// skipping news the target has pinned already.
+func mergePins(ctx context.Context, repos *repositories.Repositories, fromTopicId, toTopicId uint) error {
+	now := time.Now()
+
+	sourcePins, err := repos.TopicPin.GetPins(ctx, fromTopicId)
+	if err != nil {
+		return err
+	}
+
+	sourcePins = activePins(sourcePins, now)
+	if len(sourcePins) == 0 {
+		return nil
+	}
+
+	targetPins, err := repos.TopicPin.GetPins(ctx, toTopicId)
+	if err != nil {
+		return err
+	}
+
+	pinned := map[uint]bool{}
+	pins := []entities.TopicPin{}
+	for _, pin := range append(activePins(targetPins, now), sourcePins...) {
+		if !pinned[pin.NewsId] {
+			pinned[pin.NewsId] = true
+			pins = append(pins, *pin)
+		}
+	}
+
+	return repos.TopicPin.ReplacePins(ctx, toTopicId, pins)
+}
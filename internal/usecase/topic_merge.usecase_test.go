@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"testing"
+
+	"news-topic-api/internal/delivery/data/dtos"
+)
+
+func TestMergeTopicCreatesNoDuplicatePairs(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		topics := b.topicUseCase()
+		news := b.newsUseCase()
+
+		football := createTopic(t, topics, "Football", "football")
+		soccer := createTopic(t, topics, "Soccer", "soccer")
+		both := createNews(t, news, "Tagged with both", football, soccer)
+		sourceOnly := createNews(t, news, "Tagged soccer", soccer)
+		targetOnly := createNews(t, news, "Tagged football", football)
+
+		merged, err := topics.MergeTopic(ctx, soccer.UUID, soccer.Version, dtos.MergeTopicRequest{TargetUuid: football.UUID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged.NewsMoved != 1 {
+			t.Errorf("moved %d news, want only the one tagged soccer alone", merged.NewsMoved)
+		}
+
+		for _, created := range []string{both.UUID, sourceOnly.UUID, targetOnly.UUID} {
+			got, err := news.GetByUuid(ctx, created)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Topics) != 1 || got.Topics[0].UUID != football.UUID {
+				t.Errorf("%q has topics %+v, want football once", got.Title, got.Topics)
+			}
+		}
+
+		if count := b.countRows(t, "news_topics"); count >= 0 && count != 3 {
+			t.Errorf("%d news_topics rows, want 3", count)
+		}
+
+		value := "soccer"
+		listed, total, err := news.GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 || len(listed) != 3 {
+			t.Errorf("filtering by the merged value lists %d news, want 3", total)
+		}
+	})
+}
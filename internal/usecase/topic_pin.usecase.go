@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// ErrNewsNotInTopic is returned when pinning news to a topic it does not
+// have.
+var ErrNewsNotInTopic = errors.New("news is not in this topic")
+
+var ErrNewsNotPinned = errors.New("news is not pinned to this topic")
+
+var ErrPinExpiryNotInFuture = errors.New("expires_at must be in the future")
+
+// ErrPinsMismatch is returned when a reorder does not list every pinned
+// news item exactly once, usually because the pins changed since they were
+// read.
+var ErrPinsMismatch = errors.New("news must list every pinned news item of the topic exactly once")
+
+type topicPinUseCase struct {
+	topicRepo repositories.TopicRepository
+	pinRepo   repositories.TopicPinRepository
+	uow       repositories.UnitOfWork
+	validate  *validator.Validate
+}
+
+func NewTopicPinUseCase(topicRepo repositories.TopicRepository, pinRepo repositories.TopicPinRepository, uow repositories.UnitOfWork, validate *validator.Validate) TopicPinUseCase {
+	return &topicPinUseCase{
+		topicRepo: topicRepo,
+		pinRepo:   pinRepo,
+		uow:       uow,
+		validate:  validate,
+	}
+}
+
+func (uc *topicPinUseCase) GetPins(ctx context.Context, topicUuid string) ([]*response.TopicPinResponse, error) {
+	topic, err := uc.topicRepo.GetByUuid(ctx, topicUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := uc.pinRepo.GetPins(ctx, topic.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return topicPinResponses(visiblePins(ctx, activePins(pins, now), now)), nil
+}
+
+func (uc *topicPinUseCase) PinNews(ctx context.Context, topicUuid string, dto dtos.PinNewsRequest) ([]*response.TopicPinResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if dto.ExpiresAt != nil {
+		if !dto.ExpiresAt.After(now) {
+			return nil, ErrPinExpiryNotInFuture
+		}
+		// UTC for the same reason as futurePublishAt
+		expiresAt := dto.ExpiresAt.UTC()
+		dto.ExpiresAt = &expiresAt
+	}
+
+	return uc.changePins(ctx, topicUuid, now, func(repos *repositories.Repositories, topic *entities.Topic, pins []entities.TopicPin) ([]entities.TopicPin, error) {
+		news, err := repos.News.GetByUuid(ctx, dto.NewsUuid)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkEmbargo(ctx, news, now); err != nil {
+			return nil, err
+		}
+
+		if err := repos.News.LoadTopics(ctx, news); err != nil {
+			return nil, err
+		}
+		if !hasTopic(news.Topics, topic.Id) {
+			return nil, ErrNewsNotInTopic
+		}
+
+		pin := entities.TopicPin{NewsId: news.Id, ExpiresAt: dto.ExpiresAt, CreatedAt: now}
+		for i, existing := range pins {
+			if existing.NewsId == news.Id {
+				pin.CreatedAt = existing.CreatedAt
+				pins = append(pins[:i], pins[i+1:]...)
+				break
+			}
+		}
+
+		at := len(pins)
+		if dto.Position > 0 && dto.Position <= len(pins) {
+			at = dto.Position - 1
+		}
+
+		return append(pins[:at], append([]entities.TopicPin{pin}, pins[at:]...)...), nil
+	})
+}
+
+func (uc *topicPinUseCase) UnpinNews(ctx context.Context, topicUuid string, newsUuid string) ([]*response.TopicPinResponse, error) {
+	return uc.changePins(ctx, topicUuid, time.Now(), func(repos *repositories.Repositories, topic *entities.Topic, pins []entities.TopicPin) ([]entities.TopicPin, error) {
+		for i, pin := range pins {
+			if pin.News.UUID == newsUuid {
+				if err := checkEmbargo(ctx, &pin.News, time.Now()); err != nil {
+					return nil, err
+				}
+				return append(pins[:i], pins[i+1:]...), nil
+			}
+		}
+
+		return nil, ErrNewsNotPinned
+	})
+}
+
+func (uc *topicPinUseCase) ReorderPins(ctx context.Context, topicUuid string, dto dtos.ReorderTopicPinsRequest) ([]*response.TopicPinResponse, error) {
+	if err := uc.validate.Struct(&dto); err != nil {
+		return nil, err
+	}
+
+	return uc.changePins(ctx, topicUuid, time.Now(), func(repos *repositories.Repositories, topic *entities.Topic, pins []entities.TopicPin) ([]entities.TopicPin, error) {
+		if len(dto.News) != len(pins) {
+			return nil, ErrPinsMismatch
+		}
+
+		byUuid := map[string]entities.TopicPin{}
+		for _, pin := range pins {
+			byUuid[pin.News.UUID] = pin
+		}
+
+		reordered := []entities.TopicPin{}
+		for _, newsUuid := range dto.News {
+			pin, ok := byUuid[newsUuid]
+			if !ok {
+				return nil, ErrPinsMismatch
+			}
+			delete(byUuid, newsUuid)
+			reordered = append(reordered, pin)
+		}
+
+		return reordered, nil
+	})
+}
+
+// changePins rewrites the pins of a topic in one unit of work. change gets
+// the pins that are still active at now, in order, and returns them as
+// they should be; expired pins are dropped on the way.
+func (uc *topicPinUseCase) changePins(ctx context.Context, topicUuid string, now time.Time, change func(repos *repositories.Repositories, topic *entities.Topic, pins []entities.TopicPin) ([]entities.TopicPin, error)) ([]*response.TopicPinResponse, error) {
+	var after []*entities.TopicPin
+
+	err := uc.uow.Do(ctx, func(repos *repositories.Repositories) error {
+		topic, err := repos.Topic.GetByUuid(ctx, topicUuid)
+		if err != nil {
+			return err
+		}
+
+		current, err := repos.TopicPin.GetPins(ctx, topic.Id)
+		if err != nil {
+			return err
+		}
+
+		active := activePins(current, now)
+		pins := make([]entities.TopicPin, len(active))
+		for i, pin := range active {
+			pins[i] = *pin
+		}
+
+		changed, err := change(repos, topic, pins)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.TopicPin.ReplacePins(ctx, topic.Id, changed); err != nil {
+			return err
+		}
+
+		written, err := repos.TopicPin.GetPins(ctx, topic.Id)
+		if err != nil {
+			return err
+		}
+		after = written
+
+		return recordAudit(ctx, repos, entities.AuditActionPinsChange, entities.AuditEntityTopic, topicUuid, topicPinResponses(active), topicPinResponses(after))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return topicPinResponses(visiblePins(ctx, after, now)), nil
+}
+
+// activePins leaves out the pins that have expired at now.
+func activePins(pins []*entities.TopicPin, now time.Time) []*entities.TopicPin {
+	active := []*entities.TopicPin{}
+	for _, pin := range pins {
+		if pin.ActiveAt(now) {
+			active = append(active, pin)
+		}
+	}
+
+	return active
+}
+
+// visiblePins leaves out the pins whose news checkEmbargo hides from the
+// caller.
+func visiblePins(ctx context.Context, pins []*entities.TopicPin, now time.Time) []*entities.TopicPin {
+	visible := []*entities.TopicPin{}
+	for _, pin := range pins {
+		if checkEmbargo(ctx, &pin.News, now) == nil {
+			visible = append(visible, pin)
+		}
+	}
+
+	return visible
+}
+
+func hasTopic(topics []entities.Topic, topicId uint) bool {
+	for _, topic := range topics {
+		if topic.Id == topicId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func topicPinResponses(pins []*entities.TopicPin) []*response.TopicPinResponse {
+	pinResponses := []*response.TopicPinResponse{}
+	for _, pin := range pins {
+		pinResponses = append(pinResponses, &response.TopicPinResponse{
+			Position:  pin.Position,
+			ExpiresAt: pin.ExpiresAt,
+			PinnedAt:  pin.CreatedAt,
+			News:      *toNewsResponse(&pin.News),
+		})
+	}
+
+	return pinResponses
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"news-topic-api/internal/delivery/data/dtos"
+)
+
+func TestPinsLeadTopicListingUntilTheyExpire(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		news := b.newsUseCase()
+		pins := NewTopicPinUseCase(b.repos.Topic, b.repos.TopicPin, b.repos.UnitOfWork, validator.New())
+		sport := createTopic(t, b.topicUseCase(), "Sport", "sport")
+
+		oldest := createNews(t, news, "Season preview", sport)
+		briefly := createNews(t, news, "Transfer rumours", sport)
+		middle := createNews(t, news, "Match report", sport)
+		newest := createNews(t, news, "Final tonight", sport)
+
+		if _, err := pins.PinNews(ctx, sport.UUID, dtos.PinNewsRequest{NewsUuid: oldest.UUID}); err != nil {
+			t.Fatal(err)
+		}
+		expiresAt := time.Now().Add(300 * time.Millisecond)
+		if _, err := pins.PinNews(ctx, sport.UUID, dtos.PinNewsRequest{NewsUuid: briefly.UUID, Position: 1, ExpiresAt: &expiresAt}); err != nil {
+			t.Fatal(err)
+		}
+
+		// listing returns the sport listing as uuids and pin positions, 0
+		// for news that is not pinned
+		listing := func() ([]string, []int) {
+			t.Helper()
+
+			value := "sport"
+			items, _, err := news.GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uuids, positions := []string{}, []int{}
+			for _, item := range items {
+				uuids = append(uuids, item.UUID)
+				position := 0
+				if item.PinPosition != nil {
+					position = *item.PinPosition
+				}
+				positions = append(positions, position)
+			}
+			return uuids, positions
+		}
+
+		check := func(when string, wantUuids []string, wantPositions []int) {
+			t.Helper()
+
+			uuids, positions := listing()
+			if len(uuids) != len(wantUuids) {
+				t.Fatalf("%s: listed %d news, want %d", when, len(uuids), len(wantUuids))
+			}
+			for i := range uuids {
+				if uuids[i] != wantUuids[i] || positions[i] != wantPositions[i] {
+					t.Errorf("%s: item %d is %s pinned at %d, want %s pinned at %d", when, i, uuids[i], positions[i], wantUuids[i], wantPositions[i])
+				}
+			}
+		}
+
+		// pins first in pin order, then the rest newest first
+		check("while pinned",
+			[]string{briefly.UUID, oldest.UUID, newest.UUID, middle.UUID},
+			[]int{1, 2, 0, 0})
+
+		active, err := pins.GetPins(ctx, sport.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(active) != 2 || active[0].News.UUID != briefly.UUID || active[1].News.UUID != oldest.UUID {
+			t.Errorf("got %d pins, want the briefly pinned news and then the oldest", len(active))
+		}
+
+		time.Sleep(time.Until(expiresAt) + 100*time.Millisecond)
+
+		check("after the pin expired",
+			[]string{oldest.UUID, newest.UUID, middle.UUID, briefly.UUID},
+			[]int{2, 0, 0, 0})
+
+		active, err = pins.GetPins(ctx, sport.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(active) != 1 || active[0].News.UUID != oldest.UUID {
+			t.Errorf("got %d pins after expiry, want the oldest news only", len(active))
+		}
+	})
+}
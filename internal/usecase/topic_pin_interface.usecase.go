@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+)
+
+type TopicPinUseCase interface {
+	// GetPins lists the active pins of a topic in order.
+	GetPins(ctx context.Context, topicUuid string) ([]*response.TopicPinResponse, error)
+	// The writes below return the pins of the topic as they are afterwards.
+	PinNews(ctx context.Context, topicUuid string, dto dtos.PinNewsRequest) ([]*response.TopicPinResponse, error)
+	UnpinNews(ctx context.Context, topicUuid string, newsUuid string) ([]*response.TopicPinResponse, error)
+	ReorderPins(ctx context.Context, topicUuid string, dto dtos.ReorderTopicPinsRequest) ([]*response.TopicPinResponse, error)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+)
+
+// topicIndex holds live topics by id, for walking the hierarchy. It holds
+// either every topic or some topics and those above them.
+type topicIndex map[uint]*entities.Topic
+
+// loadTopicIndex indexes every live topic. Only the whole tree needs that;
+// the other endpoints use loadTopicAncestry.
+func loadTopicIndex(ctx context.Context, topicRepo repositories.TopicRepository) (topicIndex, []*entities.Topic, error) {
+	topics, err := topicRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index := topicIndex{}
+	for _, topic := range topics {
+		index[topic.Id] = topic
+	}
+
+	return index, topics, nil
+}
+
+// loadTopicAncestry indexes topics together with the live topics above
+// them, which is all parent, ancestors and response need.
+func loadTopicAncestry(ctx context.Context, topicRepo repositories.TopicRepository, topics ...*entities.Topic) (topicIndex, error) {
+	ancestors, err := topicRepo.GetAncestors(ctx, topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	index := topicIndex{}
+	for _, topic := range ancestors {
+		index[topic.Id] = topic
+	}
+	for _, topic := range topics {
+		index[topic.Id] = topic
+	}
+
+	return index, nil
+}
+
+// parent returns the parent of topic, or nil for a root topic or one whose
+// parent is no longer there.
+func (index topicIndex) parent(topic *entities.Topic) *entities.Topic {
+	if topic.ParentId == nil {
+		return nil
+	}
+	return index[*topic.ParentId]
+}
+
+// ancestors returns the topics above topic, nearest first. The walk stops
+// at a topic it has already seen, so a broken hierarchy cannot loop.
+func (index topicIndex) ancestors(topic *entities.Topic) []*entities.Topic {
+	seen := map[uint]bool{topic.Id: true}
+
+	ancestors := []*entities.Topic{}
+	for parent := index.parent(topic); parent != nil && !seen[parent.Id]; parent = index.parent(parent) {
+		seen[parent.Id] = true
+		ancestors = append(ancestors, parent)
+	}
+
+	return ancestors
+}
+
+// response is the topic as the topic endpoints return it, with its parent
+// and breadcrumb.
+func (index topicIndex) response(topic *entities.Topic) *response.TopicResponse {
+	topicResponse := toTopicResponse(topic)
+
+	ancestors := index.ancestors(topic)
+	if len(ancestors) > 0 {
+		topicResponse.ParentUuid = &ancestors[0].UUID
+	}
+
+	topicResponse.Path = []response.TopicPathResponse{}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		topicResponse.Path = append(topicResponse.Path, topicPathStep(ancestors[i]))
+	}
+	topicResponse.Path = append(topicResponse.Path, topicPathStep(topic))
+
+	return topicResponse
+}
+
+func topicPathStep(topic *entities.Topic) response.TopicPathResponse {
+	return response.TopicPathResponse{
+		UUID:  topic.UUID,
+		Title: topic.Title,
+		Value: topic.Value,
+	}
+}
+
+// tree nests topics, which must be in display order, under their parents.
+// Topics whose parent is gone are shown as roots.
+func (index topicIndex) tree(topics []*entities.Topic) []*response.TopicTreeResponse {
+	nodes := map[uint]*response.TopicTreeResponse{}
+	for _, topic := range topics {
+		nodes[topic.Id] = &response.TopicTreeResponse{
+			Id:       topic.Id,
+			UUID:     topic.UUID,
+			Title:    topic.Title,
+			Value:    topic.Value,
+			Children: []*response.TopicTreeResponse{},
+		}
+	}
+
+	roots := []*response.TopicTreeResponse{}
+	for _, topic := range topics {
+		if parent := index.parent(topic); parent != nil {
+			nodes[parent.Id].Children = append(nodes[parent.Id].Children, nodes[topic.Id])
+		} else {
+			roots = append(roots, nodes[topic.Id])
+		}
+	}
+
+	return roots
+}
+
+// expandTopicFilter fills in the topic values a news filter stands for when
+// it includes descendants. The repositories resolve aliases themselves, but
+// the descendants of an alias are those of the topic it belongs to.
+func expandTopicFilter(ctx context.Context, topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, filter *dtos.FilterNewsRequest) error {
+	if filter.Topic == nil || !filter.IncludeDescendants {
+		return nil
+	}
+
+	filter.TopicValues = []string{*filter.Topic}
+
+	topic, err := liveTopicByValue(ctx, topicRepo, aliasRepo, *filter.Topic)
+	if err != nil || topic == nil {
+		return err
+	}
+
+	descendants, err := topicRepo.GetDescendants(ctx, topic.Id)
+	if err != nil {
+		return err
+	}
+
+	filter.TopicValues = []string{topic.Value}
+	for _, descendant := range descendants {
+		filter.TopicValues = append(filter.TopicValues, descendant.Value)
+	}
+
+	return nil
+}
+
+// liveTopicByValue finds the live topic a value names, either as its own
+// value or as one of its aliases, or nil when there is none.
+func liveTopicByValue(ctx context.Context, topicRepo repositories.TopicRepository, aliasRepo repositories.TopicAliasRepository, value string) (*entities.Topic, error) {
+	topic, err := topicRepo.GetByValue(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	if topic != nil && !topic.DeletedAt.Valid {
+		return topic, nil
+	}
+
+	alias, err := aliasRepo.GetByValue(ctx, value)
+	if err != nil || alias == nil {
+		return nil, err
+	}
+
+	topic, err = topicRepo.GetById(ctx, alias.TopicId)
+	if isTopicNotFound(err) {
+		return nil, nil
+	}
+
+	return topic, err
+}
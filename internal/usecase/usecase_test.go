@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/db"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/repositories"
+)
+
+// backend is a storage backend the use cases run against in tests.
+type backend struct {
+	repos *repositories.Repositories
+	// db is the SQLite database behind repos, nil for the memory store.
+	db *gorm.DB
+}
+
+// eachBackend runs test on a fresh memory store and on a fresh, migrated
+// SQLite database.
+func eachBackend(t *testing.T, test func(t *testing.T, b backend)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, backend{repos: repositories.NewRepositoriesMemory(repositories.NewMemoryStore())})
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		conn := newSQLiteDB(t)
+		test(t, backend{repos: repositories.NewRepositoriesGorm(conn, nil, repositories.Timeouts{}), db: conn})
+	})
+}
+
+// newSQLiteDB opens a fresh, migrated SQLite database that is closed when
+// the test ends.
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	conn, err := db.NewSQLiteDB(&db.Config{DBPath: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn = conn.Session(&gorm.Session{Logger: logger.Discard})
+
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+func (b backend) newsUseCase() NewsUseCase {
+	return NewNewsUseCase(b.repos.News, b.repos.Topic, b.repos.TopicAlias, b.repos.NewsReviewComment, b.repos.UnitOfWork, validator.New())
+}
+
+func (b backend) topicUseCase() TopicUseCase {
+	return NewTopicUseCase(b.repos.Topic, b.repos.TopicAlias, b.repos.UnitOfWork, validator.New())
+}
+
+// countRows counts the rows of a table on SQLite. On the memory store it
+// reports -1, there are no tables to look at.
+func (b backend) countRows(t *testing.T, table string) int64 {
+	t.Helper()
+
+	if b.db == nil {
+		return -1
+	}
+
+	var count int64
+	if err := b.db.Table(table).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// lockNews takes the edit lock on news for the actor of testContext.
+func (b backend) lockNews(t *testing.T, uuid string) {
+	t.Helper()
+
+	locks := NewNewsLockUseCase(b.repos.News, b.repos.NewsLock, b.repos.UnitOfWork, time.Minute)
+	if _, err := locks.Lock(testContext(), uuid); err != nil {
+		t.Fatalf("lock news %s: %v", uuid, err)
+	}
+}
+
+func testContext() context.Context {
+	return common.WithActor(context.Background(), "tester")
+}
+
+func firstPage() *common.Pagination {
+	return &common.Pagination{Limit: 10, Page: 1}
+}
+
+func createTopic(t *testing.T, uc TopicUseCase, title, value string) *response.TopicResponse {
+	t.Helper()
+
+	topic, err := uc.CreateTopic(testContext(), dtos.CreateTopicRequest{Title: title, Value: value})
+	if err != nil {
+		t.Fatalf("create topic %s: %v", value, err)
+	}
+	return topic
+}
+
+func createNews(t *testing.T, uc NewsUseCase, title string, topics ...*response.TopicResponse) *response.NewsResponse {
+	t.Helper()
+
+	dto := dtos.CreateNewsRequest{Title: title, Content: "content", Status: "draft"}
+	for _, topic := range topics {
+		dto.Topics = append(dto.Topics, dtos.TopicUuid{Uuid: topic.UUID})
+	}
+
+	news, err := uc.CreateNews(testContext(), dto)
+	if err != nil {
+		t.Fatalf("create news %s: %v", title, err)
+	}
+	return news
+}
+
+func TestNewsAndTopicUseCases(t *testing.T) {
+	eachBackend(t, func(t *testing.T, b backend) {
+		ctx := testContext()
+		topics := b.topicUseCase()
+		news := b.newsUseCase()
+
+		sport := createTopic(t, topics, "Sport", "sport")
+		politics := createTopic(t, topics, "Politics", "politics")
+		created := createNews(t, news, "Final tonight", sport)
+		createNews(t, news, "Elections", politics)
+
+		if _, err := topics.CreateTopic(ctx, dtos.CreateTopicRequest{Title: "Sport again", Value: "sport"}); err == nil {
+			t.Error("created a second topic with the value sport")
+		}
+
+		got, err := news.GetByUuid(ctx, created.UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != "Final tonight" || len(got.Topics) != 1 || got.Topics[0].Value != "sport" {
+			t.Errorf("got %q with topics %+v, want Final tonight with sport", got.Title, got.Topics)
+		}
+
+		value := "sport"
+		listed, total, err := news.GetAllNews(ctx, firstPage(), &dtos.FilterNewsRequest{Topic: &value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || len(listed) != 1 || listed[0].UUID != created.UUID {
+			t.Errorf("news tagged sport: got %d items, total %d, want only %s", len(listed), total, created.UUID)
+		}
+
+		if err := news.DeleteByUuid(ctx, created.UUID, created.Version); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := news.GetByUuid(ctx, created.UUID); err == nil {
+			t.Error("deleted news is still found")
+		}
+
+		trashed, total, err := news.GetTrashedNews(ctx, firstPage())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || len(trashed) != 1 || trashed[0].UUID != created.UUID {
+			t.Errorf("trash: got %d items, total %d, want only %s", len(trashed), total, created.UUID)
+		}
+	})
+}
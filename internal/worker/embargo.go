@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/usecase"
+)
+
+const (
+	embargoBatchSize = 100
+	embargoActor     = "embargo"
+)
+
+// EmbargoLifter lifts embargoes once their time has passed and records an
+// embargo_lift audit event for each. Like the Publisher, one runs in every
+// server and each item is claimed with a conditional update.
+//
+// Public reads compare embargo_until with the current time themselves, so
+// news becomes visible on time even when the lifter runs late.
+type EmbargoLifter struct {
+	embargoUseCase usecase.NewsEmbargoUseCase
+	interval       time.Duration
+}
+
+// NewEmbargoLifter returns a lifter that looks for passed embargoes every
+// interval.
+func NewEmbargoLifter(embargoUseCase usecase.NewsEmbargoUseCase, interval time.Duration) *EmbargoLifter {
+	return &EmbargoLifter{
+		embargoUseCase: embargoUseCase,
+		interval:       interval,
+	}
+}
+
+// Run lifts passed embargoes right away and then every interval until ctx
+// is done. A zero interval disables the lifter.
+func (l *EmbargoLifter) Run(ctx context.Context) {
+	if l.interval <= 0 {
+		return
+	}
+
+	ctx = common.WithActor(ctx, embargoActor)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		l.liftDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *EmbargoLifter) liftDue(ctx context.Context) {
+	lifted, err := l.embargoUseCase.LiftDue(ctx, time.Now(), embargoBatchSize)
+	if lifted > 0 {
+		log.Printf("lifted %d news embargoes", lifted)
+	}
+	if err != nil {
+		log.Printf("lifting news embargoes: %v", err)
+	}
+}
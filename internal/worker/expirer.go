@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/usecase"
+)
+
+const (
+	expireBatchSize = 100
+	expirerActor    = "expirer"
+)
+
+// Expirer archives published news once their expiry time has passed. Like
+// the Publisher, one runs in every server and each item is claimed with a
+// conditional update.
+type Expirer struct {
+	expiryUseCase usecase.NewsExpiryUseCase
+	interval      time.Duration
+}
+
+// NewExpirer returns an expirer that looks for expired news every interval.
+func NewExpirer(expiryUseCase usecase.NewsExpiryUseCase, interval time.Duration) *Expirer {
+	return &Expirer{
+		expiryUseCase: expiryUseCase,
+		interval:      interval,
+	}
+}
+
+// Run archives expired news right away and then every interval until ctx
+// is done. A zero interval disables the expirer.
+func (e *Expirer) Run(ctx context.Context) {
+	if e.interval <= 0 {
+		return
+	}
+
+	ctx = common.WithActor(ctx, expirerActor)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.archiveExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Expirer) archiveExpired(ctx context.Context) {
+	archived, err := e.expiryUseCase.ArchiveExpired(ctx, time.Now(), expireBatchSize)
+	if archived > 0 {
+		log.Printf("archived %d expired news", archived)
+	}
+	if err != nil {
+		log.Printf("archiving expired news: %v", err)
+	}
+}
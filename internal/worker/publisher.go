@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/usecase"
+)
+
+const (
+	publishBatchSize = 100
+	publisherActor   = "publisher"
+)
+
+// Publisher publishes scheduled news once their publish time has passed.
+// Every server runs one; the use case claims each item with a conditional
+// update, so publishers on several replicas never publish the same item
+// twice.
+type Publisher struct {
+	scheduleUseCase usecase.NewsScheduleUseCase
+	interval        time.Duration
+}
+
+// NewPublisher returns a publisher that looks for due news every interval.
+func NewPublisher(scheduleUseCase usecase.NewsScheduleUseCase, interval time.Duration) *Publisher {
+	return &Publisher{
+		scheduleUseCase: scheduleUseCase,
+		interval:        interval,
+	}
+}
+
+// Run publishes due news right away and then every interval until ctx is
+// done. A zero interval disables the publisher.
+func (p *Publisher) Run(ctx context.Context) {
+	if p.interval <= 0 {
+		return
+	}
+
+	ctx = common.WithActor(ctx, publisherActor)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.publishDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Publisher) publishDue(ctx context.Context) {
+	published, err := p.scheduleUseCase.PublishDue(ctx, time.Now(), publishBatchSize)
+	if published > 0 {
+		log.Printf("published %d scheduled news", published)
+	}
+	if err != nil {
+		log.Printf("publishing scheduled news: %v", err)
+	}
+}
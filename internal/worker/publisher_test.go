@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"news-topic-api/common"
+	"news-topic-api/internal/db"
+	"news-topic-api/internal/delivery/data/dtos"
+	response "news-topic-api/internal/delivery/data/responses"
+	"news-topic-api/internal/entities"
+	"news-topic-api/internal/repositories"
+	"news-topic-api/internal/usecase"
+)
+
+// eachStore runs test on a fresh memory store and on a fresh, migrated
+// SQLite database.
+func eachStore(t *testing.T, test func(t *testing.T, repos *repositories.Repositories)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, repositories.NewRepositoriesMemory(repositories.NewMemoryStore()))
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		conn, err := db.NewSQLiteDB(&db.Config{DBPath: filepath.Join(t.TempDir(), "test.db")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn = conn.Session(&gorm.Session{Logger: logger.Discard})
+
+		sqlDB, err := conn.DB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+
+		migrator, err := db.NewMigrator(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := migrator.Up(); err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, repositories.NewRepositoriesGorm(conn, nil, repositories.Timeouts{}))
+	})
+}
+
+// captureLog collects what the standard logger prints until the test ends.
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestPublisherLeavesBlockedNewsAlone(t *testing.T) {
+	eachStore(t, func(t *testing.T, repos *repositories.Repositories) {
+		ctx := common.WithActor(context.Background(), "tester")
+		news := usecase.NewNewsUseCase(repos.News, repos.Topic, repos.TopicAlias, repos.NewsReviewComment, repos.UnitOfWork, validator.New())
+		schedule := usecase.NewNewsScheduleUseCase(repos.News, repos.UnitOfWork, validator.New())
+
+		// blocked is due first, ready right after it
+		publishAt := time.Now().Add(200 * time.Millisecond)
+		scheduled := []*response.NewsResponse{}
+		for i, title := range []string{"Blocked", "Ready"} {
+			current, err := news.CreateNews(ctx, dtos.CreateNewsRequest{Title: title, Content: "content", Status: "draft"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, status := range []string{"in_review", "approved"} {
+				current, err = news.UpdateNewsStatus(ctx, current.UUID, current.Version, dtos.UpdateNewsStatus{Status: status})
+				if err != nil {
+					t.Fatalf("move %s to %s: %v", title, status, err)
+				}
+			}
+			at := publishAt.Add(time.Duration(i) * time.Millisecond)
+			current, err = schedule.ScheduleNews(ctx, current.UUID, current.Version, dtos.ScheduleNewsRequest{PublishAt: &at})
+			if err != nil {
+				t.Fatal(err)
+			}
+			scheduled = append(scheduled, current)
+		}
+		blocked, ready := scheduled[0], scheduled[1]
+
+		// the use case refuses new threads on scheduled news, this one
+		// stands in for a thread opened while the news was being scheduled
+		if _, err := repos.NewsReviewComment.CreateComment(ctx, &entities.NewsReviewComment{NewsId: blocked.Id, Author: "tester", Body: "Source?"}); err != nil {
+			t.Fatal(err)
+		}
+
+		time.Sleep(time.Until(publishAt) + 50*time.Millisecond)
+
+		logged := captureLog(t)
+		publisher := NewPublisher(schedule, time.Minute)
+		for tick := 0; tick < 2; tick++ {
+			publisher.publishDue(ctx)
+		}
+
+		if strings.Contains(logged.String(), "publishing scheduled news") {
+			t.Errorf("publisher logged an error: %s", logged)
+		}
+		if got := strings.Count(logged.String(), "published 1 scheduled news"); got != 1 {
+			t.Errorf("publisher published in %d ticks, want 1: %s", got, logged)
+		}
+
+		for uuid, want := range map[string]entities.StatusType{blocked.UUID: entities.NewsStatusScheduled, ready.UUID: entities.NewsStatusPublished} {
+			got, err := news.GetByUuid(ctx, uuid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != string(want) {
+				t.Errorf("%s is %s, want %s", got.Title, got.Status, want)
+			}
+		}
+	})
+}